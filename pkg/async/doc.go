@@ -0,0 +1,6 @@
+// Package async provides structured concurrency for go-fast handlers.
+//
+// A Group ties goroutines to a parent context.Context, typically the
+// request's, so that fan-out work is cancelled together, panics surface as
+// errors, and Wait guarantees that no goroutine outlives the group.
+package async
@@ -0,0 +1,46 @@
+package async
+
+import "context"
+
+// Future holds the eventual result of a task started with Go.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Go runs fn in g and returns a Future for its result.
+//
+// A failing or panicking fn cancels the group just like Group.Go; the error
+// is also available from Await. If the group is cancelled before fn can
+// start, the future resolves with the cancellation cause.
+func Go[T any](g *Group, fn func(ctx context.Context) (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+
+	g.start(func() error {
+		defer close(f.done)
+
+		var value T
+		err := callSafely(func() error {
+			var fnErr error
+			value, fnErr = fn(g.ctx)
+			return fnErr
+		})
+		f.value, f.err = value, err
+		return err
+	}, func(cause error) {
+		f.err = cause
+		close(f.done)
+	})
+
+	return f
+}
+
+// Await blocks until the task finishes and returns its result.
+func (f *Future[T]) Await() (T, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// Done returns a channel that is closed once the result is available.
+func (f *Future[T]) Done() <-chan struct{} { return f.done }
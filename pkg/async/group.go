@@ -0,0 +1,123 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// Group runs a set of tasks bound to a shared context.
+//
+// The first task to fail cancels the group context so sibling tasks can stop
+// early. Wait blocks until every started task has returned, which makes it
+// impossible to leak goroutines as long as Wait is called.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+
+	wg  sync.WaitGroup
+	sem chan struct{}
+
+	errOnce sync.Once
+	err     error
+}
+
+// New returns a Group whose context is derived from parent.
+//
+// Cancelling parent, for example when the client disconnects, cancels every
+// task in the group.
+func New(parent context.Context) *Group {
+	ctx, cancel := context.WithCancelCause(parent)
+	return &Group{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the group context passed to every task.
+func (g *Group) Context() context.Context { return g.ctx }
+
+// SetLimit bounds the number of tasks running at once. A value <= 0 removes
+// the limit. SetLimit must be called before the first Go.
+func (g *Group) SetLimit(n int) {
+	if n <= 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs fn in a new goroutine.
+//
+// When the group has a limit, Go blocks until a slot is free. If the group
+// context is cancelled while waiting, fn is never started.
+func (g *Group) Go(fn func(ctx context.Context) error) {
+	g.start(func() error { return fn(g.ctx) }, nil)
+}
+
+// Wait blocks until all tasks have returned, releases the group context and
+// returns the first error reported by a task.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(context.Canceled)
+	return g.err
+}
+
+// start launches run under the group's limit and bookkeeping. skipped is
+// invoked instead of run when the group is cancelled before a slot frees up.
+func (g *Group) start(run func() error, skipped func(error)) {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			if skipped != nil {
+				skipped(context.Cause(g.ctx))
+			}
+			return
+		}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		if err := callSafely(run); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+// fail records err as the group error if it is the first one and cancels the group.
+func (g *Group) fail(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		g.cancel(err)
+	})
+}
+
+// callSafely invokes fn and converts a panic into a *PanicError.
+func callSafely(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
+
+// PanicError reports a panic recovered from a task.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("async: task panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value when it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
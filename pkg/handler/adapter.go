@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -11,89 +10,172 @@ import (
 //
 // The returned closure reuses precomputed metadata and field resolvers so that
 // expensive reflection analysis happens once at startup, not on every request.
-func Adapt(fn interface{}) (http.HandlerFunc, error) {
+// The handler takes one input struct, optionally preceded by a
+// context.Context, which receives the request context. Options tune how the
+// handler is compiled; see WithPattern, WithResolver and WithMiddleware. Error
+// responses follow the WithErrorExposure policy.
+func Adapt(fn interface{}, opts ...Option) (http.HandlerFunc, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	meta, err := Analyze(fn)
 	if err != nil {
 		return nil, err
 	}
 
-	if meta.NumInputs != 1 {
-		return nil, fmt.Errorf("handler must have exactly 1 input, got %d", meta.NumInputs)
+	takesContext := meta.NumInputs == 2 && meta.InputTypes[0] == contextType
+	if meta.NumInputs != 1 && !takesContext {
+		return nil, fmt.Errorf("handler must have exactly 1 input, optionally preceded by context.Context, got %d", meta.NumInputs)
 	}
 
-	inputType := meta.InputTypes[0]
+	inputType := meta.InputTypes[meta.NumInputs-1]
 	if inputType.Kind() != reflect.Struct {
 		return nil, fmt.Errorf("handler input must be a struct, got %s", inputType.Kind())
 	}
 
-	resolvers, bodyFieldIdx, err := buildResolvers(inputType)
+	if err := validateOutputs(meta); err != nil {
+		return nil, err
+	}
+
+	plan, err := buildResolvers(inputType, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := &Context{Request: r, Params: map[string]string{}}
-		paramValue := reflect.New(inputType).Elem()
+	injectsWriter := plan.injectsWriter()
 
-		if bodyFieldIdx >= 0 {
-			bodyResolver := resolverByFieldIndex(resolvers, bodyFieldIdx)
-			if bodyResolver == nil {
-				writeError(w, http.StatusInternalServerError, "body resolver missing")
-				return
-			}
+	// Handlers without results never encode a body, so they accept any Accept.
+	negotiates := meta.NumOutputs > 0 && !(meta.ReturnsError && meta.NumOutputs == 1)
 
-			val, resolveErr := bodyResolver.Resolve(ctx)
-			if resolveErr != nil {
-				writeError(w, http.StatusBadRequest, resolveErr.Error())
-				return
+	invoke := chainMiddleware(cfg.middleware, func(call *Call) error {
+		r := call.Request
+		call.bound = r
+		if negotiates {
+			renderer, negotiateErr := negotiate(cfg.renderers, r)
+			if negotiateErr != nil {
+				return negotiateErr
 			}
+			call.renderer = renderer
+		}
 
-			if setErr := setResolvedField(paramValue, bodyResolver.FieldIndex(), val); setErr != nil {
-				writeError(w, http.StatusBadRequest, setErr.Error())
-				return
-			}
+		params := ParamsFromRequest(r)
+		if params == nil {
+			params = map[string]string{}
 		}
 
-		for _, resolver := range resolvers {
-			if resolver.FieldIndex() == bodyFieldIdx {
-				continue
-			}
+		ctx := &Context{Request: r, Params: params, MultipartMemory: cfg.multipartMemory}
+		paramValue := reflect.New(inputType).Elem()
 
-			val, resolveErr := resolver.Resolve(ctx)
-			if resolveErr != nil {
-				writeError(w, http.StatusBadRequest, resolveErr.Error())
-				return
-			}
+		if resolveErr := plan.resolve(ctx, paramValue); resolveErr != nil {
+			return resolveErr
+		}
+		if injectErr := plan.inject(call, paramValue); injectErr != nil {
+			return injectErr
+		}
+		call.Input = paramValue
 
-			if setErr := setResolvedField(paramValue, resolver.FieldIndex(), val); setErr != nil {
-				writeError(w, http.StatusBadRequest, setErr.Error())
-				return
+		if plan.validator != nil {
+			var validationErrs ValidationErrors
+			plan.validator.validate(paramValue, "", &validationErrs)
+			if len(validationErrs) > 0 {
+				return validationErrs
 			}
 		}
 
-		results := meta.FuncValue.Call([]reflect.Value{paramValue})
+		args := []reflect.Value{paramValue}
+		if takesContext {
+			args = []reflect.Value{reflect.ValueOf(r.Context()), paramValue}
+		}
+		results := meta.FuncValue.Call(args)
+		call.invoked = true
 
 		if meta.ReturnsError {
 			errVal := results[len(results)-1]
+			call.Results = results[:len(results)-1]
 			if !errVal.IsNil() {
-				writeError(w, http.StatusInternalServerError, errVal.Interface().(error).Error())
-				return
+				return errVal.Interface().(error)
 			}
+			return nil
 		}
 
-		nonErrorResults := meta.NumOutputs
-		if meta.ReturnsError {
-			nonErrorResults--
+		call.Results = results
+		return nil
+	})
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cfg.maxBodySize > 0 && r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, cfg.maxBodySize)
+		}
+		var tracker *responseTracker
+		if injectsWriter {
+			tracker = &responseTracker{ResponseWriter: w}
+			w = tracker
+		}
+		call := &Call{Request: r, Writer: w}
+		defer call.removeTempFiles()
+
+		callErr := invoke(call)
+		if tracker != nil && tracker.written {
+			// The handler took over the response; an error can only be logged.
+			if callErr != nil {
+				cfg.logError(r, callErr)
+			}
+			return
+		}
+		if callErr != nil {
+			cfg.writeError(w, r, callErr)
+			return
 		}
 
-		if nonErrorResults == 0 {
+		if !call.invoked {
+			// A middleware short-circuited the chain and wrote the response.
+			return
+		}
+
+		if len(call.Results) == 0 {
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		if encodeErr := json.NewEncoder(w).Encode(results[0].Interface()); encodeErr != nil {
-			writeError(w, http.StatusInternalServerError, encodeErr.Error())
+		if call.renderer == nil {
+			call.renderer = &cfg.renderers[0]
+		}
+		if len(cfg.renderers) > 1 {
+			w.Header().Add("Vary", "Accept")
+		}
+		if writeErr := writeResult(w, call.renderer, call.Results[0].Interface()); writeErr != nil {
+			cfg.writeError(w, r, writeErr)
 		}
 	}, nil
 }
+
+// removeTempFiles deletes the temporary files of a multipart form parsed
+// while resolving the input. Adapt defers it so that it runs once the
+// response is written, including after a binding error or a handler panic;
+// uploads must therefore be consumed before the handler returns.
+func (c *Call) removeTempFiles() {
+	if c.bound != nil && c.bound.MultipartForm != nil {
+		_ = c.bound.MultipartForm.RemoveAll()
+	}
+}
+
+// validateOutputs checks that a Response[T] return is the handler's only
+// non-error output.
+func validateOutputs(meta *HandlerMetadata) error {
+	results := meta.OutputTypes
+	if meta.ReturnsError {
+		results = results[:len(results)-1]
+	}
+
+	for i, out := range results {
+		if out.Kind() == reflect.Pointer && isResponseType(out.Elem()) {
+			return fmt.Errorf("handler output %d must be %s, not a pointer to it", i, out.Elem())
+		}
+		if isResponseType(out) && len(results) != 1 {
+			return fmt.Errorf("handler returning %s must not have other non-error outputs", out)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	handlerResolvers "github.com/sohamratnaparkhi/go-fast/pkg/handler/resolvers"
+)
+
+// ResolveError is the typed error returned by the built-in resolvers.
+type ResolveError = handlerResolvers.ResolveError
+
+// FormatError reports a value that does not have the expected format, such
+// as a malformed time. Its Expected text is included in client messages.
+type FormatError = handlerResolvers.FormatError
+
+// BindingError reports a failure to bind one input field from the request.
+type BindingError struct {
+	// Field is the public name of the field, e.g. the query parameter name,
+	// or "body" for the request body.
+	Field string
+	// Source is the tag source the field is bound from, e.g. "query".
+	Source string
+	// Value is the raw request value that could not be bound, if any.
+	Value string
+	// Err is the underlying cause.
+	Err error
+}
+
+var _ HTTPError = (*BindingError)(nil)
+
+func (e *BindingError) Error() string         { return e.Err.Error() }
+func (e *BindingError) Unwrap() error         { return e.Err }
+func (e *BindingError) PublicMessage() string { return safeBindingMessage(e.Source, e.Field, e.Err) }
+func (e *BindingError) Details() any          { return nil }
+func (e *BindingError) Headers() http.Header  { return nil }
+
+// StatusCode is 413 for a body over the WithMaxBodySize or WithMultipartMemory
+// limits and for uploads over a maxsize or maxcount option, 415 for a body
+// with an unsupported Content-Type or an upload of a type outside its types
+// option, and 400 otherwise.
+func (e *BindingError) StatusCode() int {
+	switch {
+	case isBodyTooLarge(e.Err),
+		errors.Is(e.Err, handlerResolvers.ErrFileTooLarge),
+		errors.Is(e.Err, handlerResolvers.ErrTooManyFiles):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(e.Err, handlerResolvers.ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusBadRequest
+}
+
+// isBodyTooLarge reports whether err comes from an exceeded body limit or a
+// multipart form too large for the WithMultipartMemory limit.
+func isBodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge) || errors.Is(err, multipart.ErrMessageTooLarge)
+}
+
+// isRequestLevel reports whether err concerns the request as a whole rather
+// than one field, so it is reported alone even when aggregating.
+func isRequestLevel(err error) bool {
+	return isBodyTooLarge(err) || errors.Is(err, handlerResolvers.ErrUnsupportedMediaType)
+}
+
+// BindingErrors lists every field that failed to bind. It is returned
+// instead of a single *BindingError when WithAllBindingErrors is set.
+type BindingErrors []BindingError
+
+func (e BindingErrors) Error() string {
+	parts := make([]string, len(e))
+	for i := range e {
+		parts[i] = e[i].Error()
+	}
+	return strings.Join(parts, "; ")
+}
+
+var _ HTTPError = BindingErrors(nil)
+
+func (e BindingErrors) PublicMessage() string { return "binding failed" }
+func (e BindingErrors) Details() any          { return nil }
+func (e BindingErrors) Headers() http.Header  { return nil }
+
+// StatusCode is 413 when any field read past the body limit, the status of
+// the only error when there is one, and 400 otherwise.
+func (e BindingErrors) StatusCode() int {
+	if len(e) == 1 {
+		return e[0].StatusCode()
+	}
+	for i := range e {
+		if isBodyTooLarge(e[i].Err) {
+			return http.StatusRequestEntityTooLarge
+		}
+	}
+	return http.StatusBadRequest
+}
+
+// fieldErrors converts e into the stable wire shape listed under "fields".
+// Messages are the generic public ones unless verbose is set.
+func (e BindingErrors) fieldErrors(verbose bool) any {
+	items := make([]bindingErrorItem, len(e))
+	for i := range e {
+		msg := e[i].PublicMessage()
+		if verbose {
+			msg = e[i].Error()
+		}
+		items[i] = bindingErrorItem{Field: e[i].Field, Source: e[i].Source, Value: e[i].Value, Message: msg}
+	}
+	return items
+}
+
+// Unwrap exposes the individual causes to errors.Is and errors.As.
+func (e BindingErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i := range e {
+		errs[i] = &e[i]
+	}
+	return errs
+}
+
+// bindingErrorItem is the wire form of one BindingError.
+type bindingErrorItem struct {
+	Field   string `json:"field"`
+	Source  string `json:"source"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+// newBindingError describes a failure of field, taking the raw value from a
+// ResolveError when the resolver provides one.
+func newBindingError(field boundField, err error) *BindingError {
+	be := &BindingError{Field: field.name, Source: field.source, Err: err}
+
+	var re *ResolveError
+	if errors.As(err, &re) {
+		be.Value = re.Raw
+		// Deep fields report the key that failed, e.g. filter[page].
+		if re.Name != "" {
+			be.Field = re.Name
+		}
+	}
+	return be
+}
+
+// WithAllBindingErrors makes Adapt run every resolver even after one fails and
+// respond with all binding errors at once, so clients can fix a form in one
+// round trip. The 400 response lists each field with its source, raw value
+// and message:
+//
+//	{"error":"binding failed","fields":[{"field":"page","source":"query","value":"abc","message":"..."}]}
+func WithAllBindingErrors() Option {
+	return func(c *config) { c.aggregateBindingErrors = true }
+}
+
+// sourceLabels names tag sources in client-facing messages.
+var sourceLabels = map[string]string{
+	"query":  "query parameter",
+	"header": "header",
+	"path":   "path parameter",
+	"cookie": "cookie",
+	"form":   "form field",
+	"file":   "file",
+}
+
+// safeBindingMessage describes a binding failure without exposing the
+// underlying error, which may name Go types or parser internals.
+func safeBindingMessage(source, field string, err error) string {
+	if isBodyTooLarge(err) {
+		return "request body too large"
+	}
+	if source == "body" || source == "multipart" {
+		if errors.Is(err, handlerResolvers.ErrUnsupportedMediaType) {
+			return "unsupported media type"
+		}
+		return "invalid request body"
+	}
+
+	label, ok := sourceLabels[source]
+	if !ok {
+		label = source
+	}
+	if errors.Is(err, handlerResolvers.ErrNotFound) || errors.Is(err, http.ErrNoCookie) {
+		return fmt.Sprintf("missing %s %q", label, field)
+	}
+	var formatErr *FormatError
+	if errors.As(err, &formatErr) {
+		return fmt.Sprintf("invalid %s %q: expected %s", label, field, formatErr.Expected)
+	}
+	return fmt.Sprintf("invalid %s %q", label, field)
+}
@@ -1,6 +1,29 @@
 package handler
 
-import handlerresolvers "github.com/sohamratnaparkhi/go-fast/pkg/handler/resolvers"
+import (
+	"context"
+	"net/http"
+
+	handlerresolvers "github.com/sohamratnaparkhi/go-fast/pkg/handler/resolvers"
+)
 
 // Context is kept as a public alias for backward compatibility.
 type Context = handlerresolvers.Context
+
+// paramsKey is the request context key under which routers store path parameters.
+type paramsKey struct{}
+
+// WithParams returns a shallow copy of r carrying router-extracted path parameters.
+//
+// Adapt reads these parameters into Context.Params so that json:"path:<name>"
+// fields can be resolved. Routers call this before invoking an adapted handler.
+func WithParams(r *http.Request, params map[string]string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), paramsKey{}, params))
+}
+
+// ParamsFromRequest returns the path parameters attached by WithParams, or nil
+// when the request was not dispatched through a router.
+func ParamsFromRequest(r *http.Request) map[string]string {
+	params, _ := r.Context().Value(paramsKey{}).(map[string]string)
+	return params
+}
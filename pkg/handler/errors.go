@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+)
+
+// HTTPError is implemented by errors that choose their own HTTP response.
+//
+// Adapt detects it with errors.As, so handlers and middleware may return it
+// directly or wrapped. Errors that do not implement HTTPError become a 500
+// response whose body never includes the error text.
+type HTTPError interface {
+	error
+	// StatusCode is the HTTP status to respond with.
+	StatusCode() int
+	// PublicMessage is the message safe to show to clients.
+	PublicMessage() string
+	// Details is optional structured data included in the response, or nil.
+	Details() any
+	// Headers are added to the response, or nil.
+	Headers() http.Header
+}
+
+// Error is the HTTPError implementation returned by NewError and the
+// status-specific constructors such as NotFound.
+type Error struct {
+	Status  int
+	Message string
+	Detail  any
+	Header  http.Header
+	// Cause is the internal error, if any. It is available to errors.Is,
+	// errors.As and logging, but is never written to the client.
+	Cause error
+}
+
+var _ HTTPError = (*Error)(nil)
+
+// NewError returns an error that responds with status and msg. An empty msg
+// defaults to the status text.
+func NewError(status int, msg string) *Error {
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+	return &Error{Status: status, Message: msg}
+}
+
+// BadRequest returns a 400 error.
+func BadRequest(msg string) *Error { return NewError(http.StatusBadRequest, msg) }
+
+// Unauthorized returns a 401 error.
+func Unauthorized(msg string) *Error { return NewError(http.StatusUnauthorized, msg) }
+
+// Forbidden returns a 403 error.
+func Forbidden(msg string) *Error { return NewError(http.StatusForbidden, msg) }
+
+// NotFound returns a 404 error.
+func NotFound(msg string) *Error { return NewError(http.StatusNotFound, msg) }
+
+// Conflict returns a 409 error.
+func Conflict(msg string) *Error { return NewError(http.StatusConflict, msg) }
+
+// UnprocessableEntity returns a 422 error.
+func UnprocessableEntity(msg string) *Error { return NewError(http.StatusUnprocessableEntity, msg) }
+
+// TooManyRequests returns a 429 error.
+func TooManyRequests(msg string) *Error { return NewError(http.StatusTooManyRequests, msg) }
+
+// ServiceUnavailable returns a 503 error.
+func ServiceUnavailable(msg string) *Error { return NewError(http.StatusServiceUnavailable, msg) }
+
+// WithDetails attaches structured details to the response body.
+func (e *Error) WithDetails(details any) *Error {
+	e.Detail = details
+	return e
+}
+
+// WithHeader adds a response header, e.g. Retry-After or WWW-Authenticate.
+func (e *Error) WithHeader(key, value string) *Error {
+	if e.Header == nil {
+		e.Header = http.Header{}
+	}
+	e.Header.Add(key, value)
+	return e
+}
+
+// WithCause records the internal error behind e without exposing it.
+func (e *Error) WithCause(err error) *Error {
+	e.Cause = err
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return e.Message + ": " + e.Cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error         { return e.Cause }
+func (e *Error) StatusCode() int       { return e.Status }
+func (e *Error) PublicMessage() string { return e.Message }
+func (e *Error) Details() any          { return e.Detail }
+func (e *Error) Headers() http.Header  { return e.Header }
+
+// ErrorResponse is the format-neutral description of an error response that
+// an ErrorRenderer turns into bytes on the wire.
+type ErrorResponse struct {
+	Status int
+	// Message is the client-safe message.
+	Message string
+	// Details holds HTTPError.Details, or nil.
+	Details any
+	// Fields lists per-field binding or validation failures, or nil.
+	Fields any
+	// Headers holds HTTPError.Headers. Adapt adds them to the response before
+	// the renderer runs.
+	Headers http.Header
+	// CorrelationID identifies the failure in server logs.
+	CorrelationID string
+	// Err is the original error. Renderers must not expose it to clients.
+	Err error
+}
+
+// fieldErrorLister is implemented by errors that report per-field failures.
+type fieldErrorLister interface {
+	fieldErrors(verbose bool) any
+}
+
+// describeError maps err to the response the client should receive.
+//
+// Only client-safe messages are used unless verbose is set, in which case
+// the full error text is exposed as in development.
+func describeError(err error, verbose bool) *ErrorResponse {
+	var he HTTPError
+	if !errors.As(err, &he) {
+		resp := &ErrorResponse{
+			Status:  http.StatusInternalServerError,
+			Message: http.StatusText(http.StatusInternalServerError),
+			Err:     err,
+		}
+		if verbose {
+			resp.Message = err.Error()
+		}
+		return resp
+	}
+
+	resp := &ErrorResponse{
+		Status:  he.StatusCode(),
+		Message: he.PublicMessage(),
+		Details: he.Details(),
+		Headers: he.Headers(),
+		Err:     err,
+	}
+	if verbose {
+		resp.Message = err.Error()
+	}
+	if lister, ok := he.(fieldErrorLister); ok {
+		resp.Fields = lister.fieldErrors(verbose)
+	}
+	return resp
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+)
+
+// ErrorExposure controls how much of an error reaches the client.
+type ErrorExposure int
+
+const (
+	// ExposeProduction writes only client-safe messages: HTTPError public
+	// messages, generic binding messages and "Internal Server Error" for
+	// everything else. The real error is logged with a correlation ID that is
+	// also returned to the client. It is the default.
+	ExposeProduction ErrorExposure = iota
+	// ExposeDevelopment writes the full error text, including decoder and
+	// handler errors. Use it only where clients are trusted.
+	ExposeDevelopment
+)
+
+// CorrelationIDHeader carries the identifier that ties an error response to
+// its log entry. An incoming value is reused; otherwise one is generated.
+const CorrelationIDHeader = "X-Request-ID"
+
+// WithErrorExposure sets the error exposure policy; the default is
+// ExposeProduction.
+func WithErrorExposure(mode ErrorExposure) Option {
+	return func(c *config) {
+		c.exposure = mode
+	}
+}
+
+// WithLogger sets the logger that records error responses; the default is
+// slog.Default(). Server errors are logged at error level, client errors at
+// info level.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// writeError describes err according to the exposure policy, logs it with a
+// correlation ID and renders the response.
+func (c *config) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	resp := c.logError(r, err)
+	w.Header().Set(CorrelationIDHeader, resp.CorrelationID)
+	writeErrorResponse(w, r, c.errorRenderer, resp)
+}
+
+// logError describes and logs err without writing a response, for errors
+// returned after the handler has written the response itself.
+func (c *config) logError(r *http.Request, err error) *ErrorResponse {
+	resp := describeError(err, c.exposure == ExposeDevelopment)
+	resp.CorrelationID = correlationID(r)
+
+	level := slog.LevelInfo
+	if resp.Status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	c.logger.LogAttrs(r.Context(), level, "request failed",
+		slog.String("correlation_id", resp.CorrelationID),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Int("status", resp.Status),
+		slog.String("error", err.Error()),
+	)
+	return resp
+}
+
+// correlationID returns the request's X-Request-ID or a new random ID.
+func correlationID(r *http.Request) string {
+	if id := r.Header.Get(CorrelationIDHeader); id != "" && len(id) <= 128 {
+		return id
+	}
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
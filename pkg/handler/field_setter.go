@@ -5,14 +5,27 @@ import (
 	"reflect"
 )
 
-// setResolvedField sets a resolved value onto target struct field by index.
+// setResolvedField sets a resolved value onto the target struct field at the
+// index path, allocating nil pointers to parameter groups along the way.
 //
 // The function accepts assignable types directly and also supports conversion
 // when reflect determines conversion is safe.
-func setResolvedField(target reflect.Value, fieldIndex int, resolvedValue reflect.Value) error {
-	field := target.Field(fieldIndex)
+func setResolvedField(target reflect.Value, index []int, resolvedValue reflect.Value) error {
+	field := target
+	for depth, i := range index {
+		if depth > 0 && field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				if !field.CanSet() {
+					return fmt.Errorf("cannot allocate parameter group at index %v", index[:depth])
+				}
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+		}
+		field = field.Field(i)
+	}
 	if !field.CanSet() {
-		return fmt.Errorf("cannot set field at index %d", fieldIndex)
+		return fmt.Errorf("cannot set field at index %v", index)
 	}
 
 	if resolvedValue.Type().AssignableTo(field.Type()) {
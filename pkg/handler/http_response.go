@@ -5,9 +5,68 @@ import (
 	"net/http"
 )
 
-// writeError writes a standard JSON error payload.
-func writeError(w http.ResponseWriter, status int, msg string) {
-	w.Header().Set("Content-Type", "application/json")
+// ErrorRenderer writes error responses for adapted handlers.
+//
+// Every failure an adapted handler can produce — resolver and field-set
+// failures, validation errors, handler errors and response encoding errors —
+// is described as an ErrorResponse and passed to the configured renderer.
+type ErrorRenderer interface {
+	RenderError(w http.ResponseWriter, r *http.Request, resp *ErrorResponse)
+}
+
+// ErrorRendererFunc adapts a function to the ErrorRenderer interface.
+type ErrorRendererFunc func(w http.ResponseWriter, r *http.Request, resp *ErrorResponse)
+
+func (f ErrorRendererFunc) RenderError(w http.ResponseWriter, r *http.Request, resp *ErrorResponse) {
+	f(w, r, resp)
+}
+
+// WithErrorRenderer replaces the default JSONErrorRenderer.
+func WithErrorRenderer(renderer ErrorRenderer) Option {
+	return func(c *config) {
+		if renderer != nil {
+			c.errorRenderer = renderer
+		}
+	}
+}
+
+// JSONErrorRenderer is the default renderer. It writes
+// {"error": "...", "fields": [...], "details": ...} with the fields and
+// details members omitted when empty.
+type JSONErrorRenderer struct{}
+
+var _ ErrorRenderer = JSONErrorRenderer{}
+
+// errorBody is the standard JSON error payload.
+type errorBody struct {
+	Error         string `json:"error"`
+	Fields        any    `json:"fields,omitempty"`
+	Details       any    `json:"details,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+func (JSONErrorRenderer) RenderError(w http.ResponseWriter, r *http.Request, resp *ErrorResponse) {
+	writeJSON(w, "application/json", resp.Status, errorBody{
+		Error:         resp.Message,
+		Fields:        resp.Fields,
+		Details:       resp.Details,
+		CorrelationID: resp.CorrelationID,
+	})
+}
+
+// writeErrorResponse adds resp's headers and renders it with renderer.
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, renderer ErrorRenderer, resp *ErrorResponse) {
+	for key, values := range resp.Headers {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	renderer.RenderError(w, r, resp)
+}
+
+// writeJSON writes v as a JSON response with the given content type and status.
+func writeJSON(w http.ResponseWriter, contentType string, status int, v any) {
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+	_ = json.NewEncoder(w).Encode(v)
 }
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// contextType is used to detect a leading context.Context handler parameter.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// injectKind identifies a request primitive bound to an untagged input field.
+type injectKind int
+
+const (
+	injectContext injectKind = iota
+	injectRequest
+	injectWriter
+	injectBody
+	injectQuery
+	injectHeader
+)
+
+// injectableTypes maps the types of untagged fields that Adapt fills from the
+// request itself to what they receive.
+var injectableTypes = map[reflect.Type]injectKind{
+	contextType:                          injectContext,
+	reflect.TypeOf((*http.Request)(nil)): injectRequest,
+	reflect.TypeOf((*http.ResponseWriter)(nil)).Elem(): injectWriter,
+	reflect.TypeOf((*io.Reader)(nil)).Elem():           injectBody,
+	reflect.TypeOf(url.Values(nil)):                    injectQuery,
+	reflect.TypeOf(http.Header(nil)):                   injectHeader,
+}
+
+// injectedField is an untagged input field filled with a request primitive.
+type injectedField struct {
+	index []int
+	kind  injectKind
+}
+
+// compileInjectedFields finds the untagged fields of inputType, including
+// those of parameter groups, whose type is one of the injectable primitives:
+// context.Context, *http.Request, http.ResponseWriter, io.Reader (the request
+// body), url.Values (the query) and http.Header.
+//
+// fields are the tagged fields already compiled; an io.Reader field cannot be
+// combined with fields that consume the body.
+func compileInjectedFields(inputType reflect.Type, fields []boundField) ([]injectedField, error) {
+	inputs, err := inputFields(inputType)
+	if err != nil {
+		return nil, err
+	}
+
+	var injected []injectedField
+	bodyField := ""
+	for _, field := range inputs {
+		if _, tagged := field.Tag.Lookup("json"); tagged {
+			continue
+		}
+		kind, ok := injectableTypes[field.Type]
+		if !ok {
+			continue
+		}
+		if !field.IsExported() {
+			return nil, fmt.Errorf("field %q of type %s is injected but not exported", field.Name, field.Type)
+		}
+
+		if kind == injectBody {
+			if bodyField != "" {
+				return nil, fmt.Errorf("multiple io.Reader fields found: %q and %q", bodyField, field.Name)
+			}
+			for _, f := range fields {
+				switch f.source {
+				case "body", "form", "file", "multipart":
+					return nil, fmt.Errorf("cannot combine io.Reader field %q with body, form, file or multipart fields: each consumes the request body", field.Name)
+				}
+			}
+			bodyField = field.Name
+		}
+		injected = append(injected, injectedField{index: field.Index, kind: kind})
+	}
+	return injected, nil
+}
+
+// inject sets the injected fields of target from call.
+func (p *resolverPlan) inject(call *Call, target reflect.Value) error {
+	r := call.Request
+	for _, field := range p.injected {
+		var value any
+		switch field.kind {
+		case injectContext:
+			value = r.Context()
+		case injectRequest:
+			value = r
+		case injectWriter:
+			value = call.Writer
+		case injectBody:
+			if r.Body == nil {
+				value = http.NoBody
+			} else {
+				value = r.Body
+			}
+		case injectQuery:
+			value = r.URL.Query()
+		case injectHeader:
+			value = r.Header
+		}
+		if err := setResolvedField(target, field.index, reflect.ValueOf(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// injectsWriter reports whether the plan hands the http.ResponseWriter to the
+// handler.
+func (p *resolverPlan) injectsWriter() bool {
+	for _, field := range p.injected {
+		if field.kind == injectWriter {
+			return true
+		}
+	}
+	return false
+}
+
+// responseTracker records whether a handler that took the
+// http.ResponseWriter wrote the response itself, in which case Adapt must not
+// write the results or an error response after it.
+type responseTracker struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (t *responseTracker) WriteHeader(status int) {
+	t.written = true
+	t.ResponseWriter.WriteHeader(status)
+}
+
+func (t *responseTracker) Write(b []byte) (int, error) {
+	t.written = true
+	return t.ResponseWriter.Write(b)
+}
+
+// Flush lets streaming handlers flush through the tracker.
+func (t *responseTracker) Flush() {
+	t.written = true
+	_ = http.NewResponseController(t.ResponseWriter).Flush()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (t *responseTracker) Unwrap() http.ResponseWriter { return t.ResponseWriter }
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// Call describes one invocation of an adapted handler as seen by middleware.
+type Call struct {
+	Request *http.Request
+	Writer  http.ResponseWriter
+
+	// Input is the resolved input struct. It is valid once next has returned
+	// without a binding error.
+	Input reflect.Value
+
+	// Results holds the handler's return values, excluding a trailing error.
+	// Middleware may replace them before the response is written.
+	Results []reflect.Value
+
+	// invoked reports whether the user function ran.
+	invoked bool
+	// renderer encodes Results; it is chosen from the Accept header before
+	// the input is resolved.
+	renderer *mediaRenderer
+	// bound is the request the input was resolved from. Middleware may have
+	// replaced Request, so its multipart form is tracked here for cleanup.
+	bound *http.Request
+}
+
+// Middleware runs around field resolution and handler invocation.
+//
+// Calling next resolves the input and invokes the rest of the chain, ending
+// with the user function; it returns the binding or handler error. The error
+// a middleware returns is the one written to the client, so middleware can
+// translate, suppress or replace errors. A middleware that returns without
+// calling next short-circuits the request; if it also returns nil, it is
+// responsible for writing the response itself.
+type Middleware func(call *Call, next func() error) error
+
+// WithMiddleware appends middleware to the handler's chain.
+//
+// Middleware runs in the order it is registered: the first middleware is the
+// outermost. Repeated WithMiddleware options accumulate, which lets routers
+// layer global, group and per-route middleware deterministically.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *config) {
+		for _, m := range mw {
+			if m != nil {
+				c.middleware = append(c.middleware, m)
+			}
+		}
+	}
+}
+
+// chainMiddleware wraps core with mw so that mw[0] runs first.
+func chainMiddleware(mw []Middleware, core func(*Call) error) func(*Call) error {
+	h := core
+	for i := len(mw) - 1; i >= 0; i-- {
+		m, next := mw[i], h
+		h = func(call *Call) error {
+			return m(call, func() error { return next(call) })
+		}
+	}
+	return h
+}
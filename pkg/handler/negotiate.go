@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Renderer encodes handler results for one media type.
+type Renderer interface {
+	Render(w io.Writer, v any) error
+}
+
+// RendererFunc adapts a function to the Renderer interface.
+type RendererFunc func(w io.Writer, v any) error
+
+func (f RendererFunc) Render(w io.Writer, v any) error { return f(w, v) }
+
+// JSONRenderer encodes results with encoding/json. It is registered for
+// application/json by default.
+type JSONRenderer struct{}
+
+func (JSONRenderer) Render(w io.Writer, v any) error { return json.NewEncoder(w).Encode(v) }
+
+// XMLRenderer encodes results with encoding/xml. It is not registered by
+// default; add it with WithRenderer("application/xml", handler.XMLRenderer{}).
+type XMLRenderer struct{}
+
+func (XMLRenderer) Render(w io.Writer, v any) error { return xml.NewEncoder(w).Encode(v) }
+
+// mediaRenderer pairs a renderer with the media type it produces.
+type mediaRenderer struct {
+	mediaType string
+	renderer  Renderer
+}
+
+// WithRenderer registers renderer for mediaType (e.g. "application/xml"),
+// replacing any renderer already registered for it.
+//
+// Results are encoded with the renderer that best matches the request's
+// Accept header; a request without one gets the first registered type, which
+// is application/json unless replaced. Options passed to router.New apply to
+// every route, so renderers can be registered per server or per route.
+func WithRenderer(mediaType string, renderer Renderer) Option {
+	return func(c *config) {
+		parsed, _, err := mime.ParseMediaType(mediaType)
+		if err != nil || strings.Contains(parsed, "*") {
+			c.err = fmt.Errorf("invalid renderer media type %q", mediaType)
+			return
+		}
+		if renderer == nil {
+			c.err = fmt.Errorf("renderer for media type %q is nil", mediaType)
+			return
+		}
+
+		for i := range c.renderers {
+			if c.renderers[i].mediaType == parsed {
+				c.renderers[i].renderer = renderer
+				return
+			}
+		}
+		c.renderers = append(c.renderers, mediaRenderer{mediaType: parsed, renderer: renderer})
+	}
+}
+
+// acceptRange is one media range of an Accept header.
+type acceptRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// matches reports whether the range covers mediaType and how specifically:
+// 3 for an exact match, 2 for type/*, 1 for */* and 0 for no match.
+func (a acceptRange) matches(mediaType string) int {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	switch {
+	case a.typ == typ && a.subtype == subtype:
+		return 3
+	case a.typ == typ && a.subtype == "*":
+		return 2
+	case a.typ == "*" && a.subtype == "*":
+		return 1
+	}
+	return 0
+}
+
+// parseAccept parses an Accept header into media ranges. Malformed ranges
+// and invalid q-values are skipped.
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(header, ",") {
+		mediaRange, params, _ := strings.Cut(part, ";")
+		typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(mediaRange)), "/")
+		if !ok || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+			continue
+		}
+
+		q := 1.0
+		valid := true
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if !strings.EqualFold(key, "q") {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				valid = false
+			}
+			q = parsed
+		}
+		if valid {
+			ranges = append(ranges, acceptRange{typ: typ, subtype: subtype, q: q})
+		}
+	}
+	return ranges
+}
+
+// negotiate picks the renderer for r's Accept header.
+//
+// Each renderer is weighted by the q-value of the most specific range that
+// matches it; the highest weight wins and ties go to the earlier registered
+// renderer. It returns a 406 error when no renderer is acceptable.
+func negotiate(renderers []mediaRenderer, r *http.Request) (*mediaRenderer, error) {
+	header := r.Header.Values("Accept")
+	if len(header) == 0 {
+		return &renderers[0], nil
+	}
+	ranges := parseAccept(strings.Join(header, ","))
+	if len(ranges) == 0 {
+		return &renderers[0], nil
+	}
+
+	var best *mediaRenderer
+	bestQ := 0.0
+	for i := range renderers {
+		specificity, q := 0, 0.0
+		for _, ar := range ranges {
+			if s := ar.matches(renderers[i].mediaType); s > specificity {
+				specificity, q = s, ar.q
+			}
+		}
+		if q > bestQ {
+			best, bestQ = &renderers[i], q
+		}
+	}
+	if best == nil {
+		available := make([]string, len(renderers))
+		for i := range renderers {
+			available[i] = renderers[i].mediaType
+		}
+		return nil, NewError(http.StatusNotAcceptable, "not acceptable").
+			WithDetails(map[string]any{"available": available})
+	}
+	return best, nil
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"mime"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Option configures how Adapt compiles a handler.
+type Option func(*config)
+
+// config holds the compile-time settings collected from Options.
+type config struct {
+	// pattern is the route pattern declared with WithPattern, if any.
+	pattern string
+	// pathParams lists the wildcard names in pattern. A nil slice disables
+	// the startup cross-check of json:"path:<name>" tags.
+	pathParams []string
+	// resolvers maps custom tag sources to the factories registered for them.
+	resolvers map[string]ResolverFactory
+	// middleware wraps resolution and invocation, outermost first.
+	middleware []Middleware
+	// aggregateBindingErrors reports every binding error instead of the first.
+	aggregateBindingErrors bool
+	// errorRenderer writes every error response.
+	errorRenderer ErrorRenderer
+	// exposure decides whether internal error text reaches clients.
+	exposure ErrorExposure
+	// logger records every error response with its correlation ID.
+	logger *slog.Logger
+	// renderers encode results, in registration order; the first is used
+	// when the request has no Accept header.
+	renderers []mediaRenderer
+	// decoders decode json:"body" fields, keyed by media type.
+	decoders map[string]Decoder
+	// maxBodySize caps the request body in bytes; 0 means unlimited.
+	maxBodySize int64
+	// multipartMemory is the part of a multipart form kept in memory; 0
+	// means the resolvers' 32 MB default.
+	multipartMemory int64
+
+	err error
+}
+
+// newConfig applies opts over the default configuration.
+func newConfig(opts []Option) (*config, error) {
+	cfg := &config{
+		errorRenderer: JSONErrorRenderer{},
+		logger:        slog.Default(),
+		renderers:     []mediaRenderer{{mediaType: "application/json", renderer: JSONRenderer{}}},
+		decoders:      map[string]Decoder{"application/json": JSONDecoder{}},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	return cfg, cfg.err
+}
+
+// WithPattern declares the route pattern the handler is registered under so
+// that every json:"path:<name>" tag is checked against its wildcards at startup.
+//
+// Both http.ServeMux patterns ("GET /users/{id}", "/files/{path...}") and
+// router patterns ("/users/:id", "/files/*path") are accepted.
+func WithPattern(pattern string) Option {
+	return func(c *config) {
+		names, err := patternParams(pattern)
+		if err != nil {
+			c.err = err
+			return
+		}
+		c.pattern = pattern
+		c.pathParams = names
+	}
+}
+
+// ResolverFactory builds a resolver for a field tagged json:"<source>:<name>"
+// (or just json:"<source>") with a source registered through WithResolver.
+type ResolverFactory func(fieldIdx int, name string, fieldType reflect.Type) (FieldResolver, error)
+
+// WithResolver registers a custom resolver factory for the given tag source.
+//
+// Built-in sources (body, header, query, path, cookie, form, file) cannot be
+// overridden. Resolvers that implement BlockingResolver are resolved
+// concurrently; see Adapt.
+func WithResolver(source string, factory ResolverFactory) Option {
+	return func(c *config) {
+		switch {
+		case source == "" || strings.ContainsAny(source, ":,"):
+			c.err = fmt.Errorf("invalid resolver source %q", source)
+		case slices.Contains(builtinSources, source):
+			c.err = fmt.Errorf("resolver source %q is built in and cannot be overridden", source)
+		case factory == nil:
+			c.err = fmt.Errorf("resolver factory for source %q is nil", source)
+		default:
+			if c.resolvers == nil {
+				c.resolvers = map[string]ResolverFactory{}
+			}
+			c.resolvers[source] = factory
+		}
+	}
+}
+
+// WithDecoder registers decoder for request bodies whose Content-Type is
+// mediaType (e.g. "application/xml"), replacing any decoder already
+// registered for it. application/json is registered by default and is used
+// when a request has no Content-Type; a body with any other unregistered
+// media type is rejected with 415 Unsupported Media Type.
+func WithDecoder(mediaType string, decoder Decoder) Option {
+	return func(c *config) {
+		parsed, _, err := mime.ParseMediaType(mediaType)
+		switch {
+		case err != nil || strings.Contains(parsed, "*"):
+			c.err = fmt.Errorf("invalid decoder media type %q", mediaType)
+		case decoder == nil:
+			c.err = fmt.Errorf("decoder for media type %q is nil", mediaType)
+		default:
+			c.decoders = maps.Clone(c.decoders)
+			c.decoders[parsed] = decoder
+		}
+	}
+}
+
+// WithMaxBodySize limits request bodies to n bytes using http.MaxBytesReader.
+// A body, form or file field that reads past the limit fails with 413
+// Request Entity Too Large.
+func WithMaxBodySize(n int64) Option {
+	return func(c *config) {
+		if n <= 0 {
+			c.err = fmt.Errorf("max body size must be positive, got %d", n)
+			return
+		}
+		c.maxBodySize = n
+	}
+}
+
+// WithMultipartMemory keeps up to n bytes of a multipart form in memory while
+// form and file fields are resolved; larger files are written to temporary
+// files. Non-file values that exceed n by more than 10 MB fail with 413
+// Request Entity Too Large, as does a body over the WithMaxBodySize limit.
+// The default is 32 MB.
+func WithMultipartMemory(n int64) Option {
+	return func(c *config) {
+		if n <= 0 {
+			c.err = fmt.Errorf("multipart memory must be positive, got %d", n)
+			return
+		}
+		c.multipartMemory = n
+	}
+}
+
+// builtinSources lists the tag sources handled by buildResolvers itself.
+var builtinSources = []string{"body", "header", "query", "path", "cookie", "form", "file", "multipart"}
+
+// patternParams extracts wildcard names from a ServeMux or router pattern.
+func patternParams(pattern string) ([]string, error) {
+	path := strings.TrimSpace(pattern)
+	if idx := strings.IndexAny(path, " \t"); idx >= 0 {
+		path = strings.TrimSpace(path[idx:])
+	}
+	if idx := strings.IndexByte(path, '/'); idx >= 0 {
+		path = path[idx:]
+	} else {
+		return nil, fmt.Errorf("pattern %q has no path", pattern)
+	}
+
+	names := []string{}
+	for _, seg := range strings.Split(path, "/") {
+		var name string
+		switch {
+		case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+			name = strings.TrimSuffix(seg[1:len(seg)-1], "...")
+			if name == "$" {
+				continue
+			}
+		case strings.HasPrefix(seg, ":"), strings.HasPrefix(seg, "*"):
+			name = seg[1:]
+		default:
+			continue
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("pattern %q has an empty wildcard name", pattern)
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
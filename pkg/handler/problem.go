@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"maps"
+	"net/http"
+)
+
+// ProblemRenderer renders errors as RFC 9457 problem details with the
+// application/problem+json media type:
+//
+//	{
+//	  "type": "about:blank",
+//	  "title": "Unprocessable Entity",
+//	  "status": 422,
+//	  "detail": "validation failed",
+//	  "instance": "/users",
+//	  "errors": [{"field": "email", "source": "body", ...}]
+//	}
+//
+// Per-field binding and validation failures are added as the "errors"
+// extension member. HTTPError details that are a map[string]any are merged
+// as extension members; any other details are added as "details".
+type ProblemRenderer struct {
+	// TypeURI returns the problem type URI for a status. When nil, or when it
+	// returns "", the type is "about:blank".
+	TypeURI func(status int) string
+}
+
+var _ ErrorRenderer = ProblemRenderer{}
+
+// reservedProblemMembers are the members extensions cannot replace.
+var reservedProblemMembers = []string{"type", "title", "status", "detail", "instance", "correlation_id"}
+
+func (p ProblemRenderer) RenderError(w http.ResponseWriter, r *http.Request, resp *ErrorResponse) {
+	problem := map[string]any{}
+
+	switch details := resp.Details.(type) {
+	case nil:
+	case map[string]any:
+		maps.Copy(problem, details)
+	default:
+		problem["details"] = details
+	}
+	if resp.Fields != nil {
+		problem["errors"] = resp.Fields
+	}
+	for _, member := range reservedProblemMembers {
+		delete(problem, member)
+	}
+
+	problemType := ""
+	if p.TypeURI != nil {
+		problemType = p.TypeURI(resp.Status)
+	}
+	if problemType == "" {
+		problemType = "about:blank"
+	}
+
+	problem["type"] = problemType
+	problem["title"] = http.StatusText(resp.Status)
+	problem["status"] = resp.Status
+	if resp.Message != "" && resp.Message != problem["title"] {
+		problem["detail"] = resp.Message
+	}
+	if r != nil && r.URL != nil {
+		problem["instance"] = r.URL.RequestURI()
+	}
+	if resp.CorrelationID != "" {
+		problem["correlation_id"] = resp.CorrelationID
+	}
+
+	writeJSON(w, "application/problem+json", resp.Status, problem)
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/sohamratnaparkhi/go-fast/pkg/async"
+)
+
+// resolve populates target from the request using the compiled plan.
+//
+// The body is resolved first. Cheap resolvers then run inline while blocking
+// resolvers run concurrently in an async.Group bound to the request context,
+// so an aborted request cancels outstanding lookups. Whatever the completion
+// order, errors are reported in struct field order: the first failing field
+// as a *BindingError, or every failing field as BindingErrors when the plan
+// aggregates errors.
+func (p *resolverPlan) resolve(ctx *Context, target reflect.Value) error {
+	values := make([]reflect.Value, len(p.fields))
+	errs := make([]error, len(p.fields))
+	failFast := !p.aggregate && !p.hasBlocking
+
+	if p.body >= 0 {
+		values[p.body], errs[p.body] = p.fields[p.body].resolver.Resolve(ctx)
+		// An oversized body or unsupported media type is reported alone even
+		// when aggregating: the other fields are irrelevant until it is fixed.
+		if errs[p.body] != nil && (!p.aggregate || isRequestLevel(errs[p.body])) {
+			return newBindingError(p.fields[p.body], errs[p.body])
+		}
+	}
+
+	var g *async.Group
+	if p.hasBlocking {
+		g = async.New(ctx.Request.Context())
+		blockingCtx := &Context{Request: ctx.Request.WithContext(g.Context()), Params: ctx.Params, MultipartMemory: ctx.MultipartMemory}
+		for i, field := range p.fields {
+			if !field.blocking {
+				continue
+			}
+			g.Go(func(context.Context) error {
+				values[i], errs[i] = field.resolver.Resolve(blockingCtx)
+				return nil
+			})
+		}
+	}
+
+	for i, field := range p.fields {
+		if field.blocking || i == p.body {
+			continue
+		}
+		values[i], errs[i] = field.resolver.Resolve(ctx)
+		if errs[i] != nil && failFast {
+			return newBindingError(field, errs[i])
+		}
+	}
+
+	if g != nil {
+		if err := g.Wait(); err != nil {
+			// Only a recovered panic reaches here; re-raise it on the request
+			// goroutine so it behaves like a panic in a synchronous resolver.
+			panic(err)
+		}
+	}
+
+	var all BindingErrors
+	for i, field := range p.fields {
+		if errs[i] == nil {
+			errs[i] = setResolvedField(target, field.index, values[i])
+		}
+		if errs[i] == nil {
+			continue
+		}
+
+		be := newBindingError(field, errs[i])
+		if !p.aggregate {
+			return be
+		}
+		all = append(all, *be)
+	}
+
+	if len(all) > 0 {
+		return all
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 type FieldIndexProvider = handlerResolvers.FieldIndexProvider
 type FieldValueResolver = handlerResolvers.FieldValueResolver
 type FieldResolver = handlerResolvers.FieldResolver
+type BlockingResolver = handlerResolvers.BlockingResolver
 
 type BodyResolver = handlerResolvers.BodyResolver
 type HeaderResolver = handlerResolvers.HeaderResolver
@@ -17,38 +18,159 @@ type PathVarResolver = handlerResolvers.PathVarResolver
 type CookieResolver = handlerResolvers.CookieResolver
 type FormResolver = handlerResolvers.FormResolver
 type FileResolver = handlerResolvers.FileResolver
+type DeepResolver = handlerResolvers.DeepResolver
+type MultipartResolver = handlerResolvers.MultipartResolver
+
+// Optional holds a query, header, path, cookie or form value together with
+// whether the request sent it:
+//
+//	func Search(req struct {
+//		Query handler.Optional[string] `json:"query:q"`
+//	}) (*Result, error) {
+//		if q, ok := req.Query.Get(); ok { ... } // ?q= is present with q == ""
+//	}
+type Optional[T any] = handlerResolvers.Optional[T]
+
+// ValueOptions tunes how query, header, path, cookie and form resolvers
+// convert values.
+type ValueOptions = handlerResolvers.ValueOptions
+
+// FileOptions constrains the uploads accepted by file resolvers.
+type FileOptions = handlerResolvers.FileOptions
+
+var (
+	// ErrFileTooLarge reports an upload over its field's maxsize option; it
+	// is answered with 413.
+	ErrFileTooLarge = handlerResolvers.ErrFileTooLarge
+	// ErrTooManyFiles reports more uploads than its field's maxcount option;
+	// it is answered with 413.
+	ErrTooManyFiles = handlerResolvers.ErrTooManyFiles
+)
+
+type Decoder = handlerResolvers.Decoder
+type DecoderFunc = handlerResolvers.DecoderFunc
+type JSONDecoder = handlerResolvers.JSONDecoder
+type XMLDecoder = handlerResolvers.XMLDecoder
+
+// ErrUnsupportedMediaType reports a request body whose Content-Type has no
+// registered Decoder; it is answered with 415.
+var ErrUnsupportedMediaType = handlerResolvers.ErrUnsupportedMediaType
+
+// RegisterConverter registers fn to convert raw query, header, path, cookie
+// and form values into type t. Converters are consulted before
+// encoding.TextUnmarshaler and the built-in kinds, and are selected when a
+// handler is adapted, so register them first, typically from init.
+func RegisterConverter(t reflect.Type, fn func(string) (any, error)) {
+	handlerResolvers.RegisterConverter(t, fn)
+}
 
 // NewBodyResolver constructs a resolver for json:"body" fields.
 func NewBodyResolver(fieldIdx int, fieldType reflect.Type) *BodyResolver {
 	return handlerResolvers.NewBodyResolver(fieldIdx, fieldType)
 }
 
+// NewBodyResolverWithDecoders constructs a resolver for json:"body" fields
+// that decodes with the decoder registered for the request's media type.
+func NewBodyResolverWithDecoders(fieldIdx int, fieldType reflect.Type, decoders map[string]Decoder) *BodyResolver {
+	return handlerResolvers.NewBodyResolverWithDecoders(fieldIdx, fieldType, decoders)
+}
+
 // NewHeaderResolver constructs a resolver for json:"header:<name>" fields.
 func NewHeaderResolver(fieldIdx int, headerName string, fieldType reflect.Type) *HeaderResolver {
 	return handlerResolvers.NewHeaderResolver(fieldIdx, headerName, fieldType)
 }
 
+// NewHeaderResolverWithOptions constructs a resolver for json:"header:<name>"
+// fields that converts values according to opts.
+func NewHeaderResolverWithOptions(fieldIdx int, headerName string, fieldType reflect.Type, opts ValueOptions) *HeaderResolver {
+	return handlerResolvers.NewHeaderResolverWithOptions(fieldIdx, headerName, fieldType, opts)
+}
+
 // NewQueryResolver constructs a resolver for json:"query:<name>" fields.
 func NewQueryResolver(fieldIdx int, queryName string, fieldType reflect.Type) *QueryResolver {
 	return handlerResolvers.NewQueryResolver(fieldIdx, queryName, fieldType)
 }
 
+// NewQueryResolverWithOptions constructs a resolver for json:"query:<name>"
+// fields that converts values according to opts.
+func NewQueryResolverWithOptions(fieldIdx int, queryName string, fieldType reflect.Type, opts ValueOptions) *QueryResolver {
+	return handlerResolvers.NewQueryResolverWithOptions(fieldIdx, queryName, fieldType, opts)
+}
+
 // NewPathVarResolver constructs a resolver for json:"path:<name>" fields.
 func NewPathVarResolver(fieldIdx int, paramName string, fieldType reflect.Type) *PathVarResolver {
 	return handlerResolvers.NewPathVarResolver(fieldIdx, paramName, fieldType)
 }
 
+// NewPathVarResolverWithOptions constructs a resolver for json:"path:<name>"
+// fields that converts values according to opts.
+func NewPathVarResolverWithOptions(fieldIdx int, paramName string, fieldType reflect.Type, opts ValueOptions) *PathVarResolver {
+	return handlerResolvers.NewPathVarResolverWithOptions(fieldIdx, paramName, fieldType, opts)
+}
+
 // NewCookieResolver constructs a resolver for json:"cookie:<name>" fields.
 func NewCookieResolver(fieldIdx int, cookieName string, fieldType reflect.Type) *CookieResolver {
 	return handlerResolvers.NewCookieResolver(fieldIdx, cookieName, fieldType)
 }
 
+// NewCookieResolverWithOptions constructs a resolver for json:"cookie:<name>"
+// fields that converts values according to opts.
+func NewCookieResolverWithOptions(fieldIdx int, cookieName string, fieldType reflect.Type, opts ValueOptions) *CookieResolver {
+	return handlerResolvers.NewCookieResolverWithOptions(fieldIdx, cookieName, fieldType, opts)
+}
+
 // NewFormResolver constructs a resolver for json:"form:<name>" fields.
 func NewFormResolver(fieldIdx int, formName string, fieldType reflect.Type) *FormResolver {
 	return handlerResolvers.NewFormResolver(fieldIdx, formName, fieldType)
 }
 
-// NewFileResolver constructs a resolver for json:"file:<name>" fields.
+// NewFormResolverWithOptions constructs a resolver for json:"form:<name>"
+// fields that converts values according to opts.
+func NewFormResolverWithOptions(fieldIdx int, formName string, fieldType reflect.Type, opts ValueOptions) *FormResolver {
+	return handlerResolvers.NewFormResolverWithOptions(fieldIdx, formName, fieldType, opts)
+}
+
+// NewDeepQueryResolver constructs a resolver for json:"query" and
+// json:"query:<prefix>,deep" fields, which decode bracketed keys such as
+// filter[status] into a struct, map or slice.
+func NewDeepQueryResolver(fieldIdx int, prefix string, fieldType reflect.Type) (*DeepResolver, error) {
+	return handlerResolvers.NewDeepQueryResolver(fieldIdx, prefix, fieldType)
+}
+
+// NewDeepFormResolver constructs a resolver for json:"form" and
+// json:"form:<prefix>,deep" fields.
+func NewDeepFormResolver(fieldIdx int, prefix string, fieldType reflect.Type) (*DeepResolver, error) {
+	return handlerResolvers.NewDeepFormResolver(fieldIdx, prefix, fieldType)
+}
+
+// NewFileResolver constructs a resolver for json:"file:<name>" fields of type
+// *multipart.FileHeader.
 func NewFileResolver(fieldIdx int, fileName string) *FileResolver {
 	return handlerResolvers.NewFileResolver(fieldIdx, fileName)
 }
+
+// NewFileResolverWithOptions constructs a resolver for json:"file:<name>"
+// fields of type *multipart.FileHeader that enforces opts.
+func NewFileResolverWithOptions(fieldIdx int, fileName string, opts FileOptions) *FileResolver {
+	return handlerResolvers.NewFileResolverWithOptions(fieldIdx, fileName, opts)
+}
+
+// NewFileListResolver constructs a resolver for json:"file:<name>" fields of
+// type []*multipart.FileHeader.
+func NewFileListResolver(fieldIdx int, fileName string) *FileResolver {
+	return handlerResolvers.NewFileListResolver(fieldIdx, fileName)
+}
+
+// NewFileListResolverWithOptions constructs a resolver for
+// json:"file:<name>" fields of type []*multipart.FileHeader that enforces
+// opts.
+func NewFileListResolverWithOptions(fieldIdx int, fileName string, opts FileOptions) *FileResolver {
+	return handlerResolvers.NewFileListResolverWithOptions(fieldIdx, fileName, opts)
+}
+
+// NewMultipartResolver constructs a resolver for json:"multipart" fields of
+// type *multipart.Reader or iter.Seq2[*multipart.Part, error], which stream
+// the request body instead of parsing it up front.
+func NewMultipartResolver(fieldIdx int, fieldType reflect.Type) (*MultipartResolver, error) {
+	return handlerResolvers.NewMultipartResolver(fieldIdx, fieldType)
+}
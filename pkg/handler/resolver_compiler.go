@@ -3,23 +3,105 @@ package handler
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	handlerResolvers "github.com/sohamratnaparkhi/go-fast/pkg/handler/resolvers"
 )
 
-// buildResolvers compiles resolver instances for tagged fields in inputType.
+// resolverPlan is the compiled resolution strategy for one input struct type.
+type resolverPlan struct {
+	// fields lists every bound field in struct field order.
+	fields []boundField
+	// body is the position of the body field in fields, or -1. It is tracked
+	// separately because request body is a one-shot reader and should be
+	// resolved first.
+	body        int
+	hasBlocking bool
+	// aggregate makes every resolver run and report all binding errors
+	// instead of stopping at the first one.
+	aggregate bool
+	// validator checks validate tags after resolution; nil when none exist.
+	validator *structValidator
+	// injected lists the untagged fields filled with request primitives.
+	injected []injectedField
+}
+
+// boundField pairs a resolver with the public identity of the field it fills.
+type boundField struct {
+	resolver FieldResolver
+	// index is the path to the field from the input struct, so fields of
+	// parameter groups are set through their enclosing structs.
+	index []int
+	// source and name come from the json tag, e.g. "query" and "page".
+	source string
+	name   string
+	// blocking marks resolvers that may block and are resolved concurrently.
+	blocking bool
+}
+
+// buildResolvers compiles resolver instances for tagged fields in inputType,
+// classifies them as cheap/synchronous or potentially blocking, finds the
+// untagged fields injected with request primitives, and compiles the validate
+// tags checked before the handler is called.
+//
+// When cfg declares a route pattern, path tags are cross-checked against its
+// wildcards so that a misspelled name fails at startup instead of per request.
+func buildResolvers(inputType reflect.Type, cfg *config) (*resolverPlan, error) {
+	fields, body, err := compileFieldResolvers(inputType, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	injected, err := compileInjectedFields(inputType, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := compileValidator(inputType)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &resolverPlan{
+		fields:    fields,
+		body:      body,
+		aggregate: cfg.aggregateBindingErrors,
+		validator: validator,
+		injected:  injected,
+	}
+	for i := range fields {
+		if b, ok := fields[i].resolver.(BlockingResolver); ok && b.Blocking() {
+			fields[i].blocking = true
+			plan.hasBlocking = true
+		}
+	}
+
+	return plan, nil
+}
+
+// compileFieldResolvers creates a resolver for every tagged field in inputType,
+// including the fields of parameter groups (see inputFields).
 //
-// It returns both resolver list and the index of the body field (if any).
-// The body index is tracked separately because request body is a one-shot reader
-// and should be resolved first.
-func buildResolvers(inputType reflect.Type) ([]FieldResolver, int, error) {
-	resolvers := make([]FieldResolver, 0, inputType.NumField())
-	bodyFieldIdx := -1
+// It returns both the bound fields and the position of the body field among
+// them (if any).
+func compileFieldResolvers(inputType reflect.Type, cfg *config) ([]boundField, int, error) {
+	inputs, err := inputFields(inputType)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	fields := make([]boundField, 0, len(inputs))
+	body := -1
+	bodyField := ""
+	streamField := ""
 	hasFormOrFile := false
 
-	for i := 0; i < inputType.NumField(); i++ {
-		field := inputType.Field(i)
+	for _, field := range inputs {
+		// Resolvers receive the index within the field's own struct; the
+		// full path is kept on the bound field.
+		i := field.Index[len(field.Index)-1]
 		tag := normalizedJSONTag(field.Tag.Get("json"))
 		if tag == "" || tag == "-" {
 			continue
@@ -29,41 +111,81 @@ func buildResolvers(inputType reflect.Type) ([]FieldResolver, int, error) {
 			return nil, -1, fmt.Errorf("field %q is tagged but not exported", field.Name)
 		}
 
+		opts := parseTagOptions(field.Tag.Get("json"))
+		valueOpts, err := compileValueOptions(field, tag, opts)
+		if err != nil {
+			return nil, -1, err
+		}
+
+		if !strings.HasPrefix(tag, "file:") && slices.ContainsFunc(fileOptionNames, func(name string) bool { _, ok := opts.Lookup(name); return ok }) {
+			return nil, -1, fmt.Errorf("maxsize, types and maxcount options on field %q are only supported for file tags", field.Name)
+		}
+
+		deep, err := deepSource(field, tag, opts)
+		if err != nil {
+			return nil, -1, err
+		}
+		if deep != "" && valueOpts != (ValueOptions{}) {
+			return nil, -1, fmt.Errorf("field %q: deep binding does not accept csv, layout, default or required options", field.Name)
+		}
+
 		switch {
+		case deep != "":
+			_, prefix, _ := strings.Cut(tag, ":")
+			var resolver *DeepResolver
+			if deep == "form" {
+				hasFormOrFile = true
+				resolver, err = NewDeepFormResolver(i, prefix, field.Type)
+			} else {
+				resolver, err = NewDeepQueryResolver(i, prefix, field.Type)
+			}
+			if err != nil {
+				return nil, -1, fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			name := prefix
+			if name == "" {
+				name = field.Name
+			}
+			fields = append(fields, boundField{index: field.Index, resolver: resolver, source: deep, name: name})
+
 		case tag == "body":
-			if bodyFieldIdx >= 0 {
-				return nil, -1, fmt.Errorf("multiple body fields found: %d and %d", bodyFieldIdx, i)
+			if bodyField != "" {
+				return nil, -1, fmt.Errorf("multiple body fields found: %q and %q", bodyField, field.Name)
 			}
-			bodyFieldIdx = i
-			resolvers = append(resolvers, NewBodyResolver(i, field.Type))
+			bodyField = field.Name
+			body = len(fields)
+			fields = append(fields, boundField{index: field.Index, resolver: NewBodyResolverWithDecoders(i, field.Type, cfg.decoders), source: "body", name: "body"})
 
 		case strings.HasPrefix(tag, "header:"):
 			name := strings.TrimPrefix(tag, "header:")
 			if name == "" {
 				return nil, -1, fmt.Errorf("header tag name cannot be empty for field %q", field.Name)
 			}
-			resolvers = append(resolvers, NewHeaderResolver(i, name, field.Type))
+			fields = append(fields, boundField{index: field.Index, resolver: NewHeaderResolverWithOptions(i, name, field.Type, valueOpts), source: "header", name: name})
 
 		case strings.HasPrefix(tag, "query:"):
 			name := strings.TrimPrefix(tag, "query:")
 			if name == "" {
 				return nil, -1, fmt.Errorf("query tag name cannot be empty for field %q", field.Name)
 			}
-			resolvers = append(resolvers, NewQueryResolver(i, name, field.Type))
+			fields = append(fields, boundField{index: field.Index, resolver: NewQueryResolverWithOptions(i, name, field.Type, valueOpts), source: "query", name: name})
 
 		case strings.HasPrefix(tag, "path:"):
 			name := strings.TrimPrefix(tag, "path:")
 			if name == "" {
 				return nil, -1, fmt.Errorf("path tag name cannot be empty for field %q", field.Name)
 			}
-			resolvers = append(resolvers, NewPathVarResolver(i, name, field.Type))
+			if cfg.pathParams != nil && !slices.Contains(cfg.pathParams, name) {
+				return nil, -1, fmt.Errorf("path tag %q on field %q does not match any wildcard in pattern %q", name, field.Name, cfg.pattern)
+			}
+			fields = append(fields, boundField{index: field.Index, resolver: NewPathVarResolverWithOptions(i, name, field.Type, valueOpts), source: "path", name: name})
 
 		case strings.HasPrefix(tag, "cookie:"):
 			name := strings.TrimPrefix(tag, "cookie:")
 			if name == "" {
 				return nil, -1, fmt.Errorf("cookie tag name cannot be empty for field %q", field.Name)
 			}
-			resolvers = append(resolvers, NewCookieResolver(i, name, field.Type))
+			fields = append(fields, boundField{index: field.Index, resolver: NewCookieResolverWithOptions(i, name, field.Type, valueOpts), source: "cookie", name: name})
 
 		case strings.HasPrefix(tag, "form:"):
 			name := strings.TrimPrefix(tag, "form:")
@@ -71,26 +193,300 @@ func buildResolvers(inputType reflect.Type) ([]FieldResolver, int, error) {
 				return nil, -1, fmt.Errorf("form tag name cannot be empty for field %q", field.Name)
 			}
 			hasFormOrFile = true
-			resolvers = append(resolvers, NewFormResolver(i, name, field.Type))
+			fields = append(fields, boundField{index: field.Index, resolver: NewFormResolverWithOptions(i, name, field.Type, valueOpts), source: "form", name: name})
 
 		case strings.HasPrefix(tag, "file:"):
 			name := strings.TrimPrefix(tag, "file:")
 			if name == "" {
 				return nil, -1, fmt.Errorf("file tag name cannot be empty for field %q", field.Name)
 			}
-			if field.Type != handlerResolvers.MultipartFileHeaderType {
-				return nil, -1, fmt.Errorf("file field %q must be *multipart.FileHeader, got %s", field.Name, field.Type)
+			fileOpts, err := compileFileOptions(field, opts)
+			if err != nil {
+				return nil, -1, err
+			}
+			var resolver *FileResolver
+			switch field.Type {
+			case handlerResolvers.MultipartFileHeaderType:
+				if fileOpts.MaxCount > 0 {
+					return nil, -1, fmt.Errorf("maxcount option on field %q requires []*multipart.FileHeader, got %s", field.Name, field.Type)
+				}
+				resolver = NewFileResolverWithOptions(i, name, fileOpts)
+			case handlerResolvers.MultipartFileHeadersType:
+				resolver = NewFileListResolverWithOptions(i, name, fileOpts)
+			default:
+				return nil, -1, fmt.Errorf("file field %q must be *multipart.FileHeader or []*multipart.FileHeader, got %s", field.Name, field.Type)
 			}
 			hasFormOrFile = true
-			resolvers = append(resolvers, NewFileResolver(i, name))
+			fields = append(fields, boundField{index: field.Index, resolver: resolver, source: "file", name: name})
+
+		case tag == "multipart":
+			if streamField != "" {
+				return nil, -1, fmt.Errorf("multiple multipart fields found: %q and %q", streamField, field.Name)
+			}
+			resolver, err := NewMultipartResolver(i, field.Type)
+			if err != nil {
+				return nil, -1, fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			streamField = field.Name
+			// The stream is the request body, so it is resolved first like one.
+			body = len(fields)
+			fields = append(fields, boundField{index: field.Index, resolver: resolver, source: "multipart", name: "multipart"})
+
+		default:
+			source, name, _ := strings.Cut(tag, ":")
+			factory, ok := cfg.resolvers[source]
+			if !ok {
+				continue
+			}
+			resolver, err := factory(i, name, field.Type)
+			if err != nil {
+				return nil, -1, fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			if resolver == nil {
+				return nil, -1, fmt.Errorf("field %q: resolver factory for source %q returned nil", field.Name, source)
+			}
+			if name == "" {
+				name = field.Name
+			}
+			fields = append(fields, boundField{index: field.Index, resolver: resolver, source: source, name: name})
 		}
 	}
 
-	if bodyFieldIdx >= 0 && hasFormOrFile {
+	if bodyField != "" && hasFormOrFile {
 		return nil, -1, fmt.Errorf("cannot combine body resolver with form/file resolvers: body consumes request body as JSON, form/file consume it as multipart or url-encoded data")
 	}
+	if streamField != "" && (bodyField != "" || hasFormOrFile) {
+		return nil, -1, fmt.Errorf("cannot combine multipart field %q with body, form or file fields: it streams the request body they would consume", streamField)
+	}
+
+	return fields, body, nil
+}
+
+// inputFields lists the fields of inputType with parameter groups flattened
+// in place, in struct field order. Each field's Index holds its full path
+// from inputType, as accepted by reflect.Value.FieldByIndex.
+//
+// A parameter group is an untagged embedded struct, or a struct field tagged
+// json:",inline"; either may also be a pointer to a struct, which is
+// allocated when its fields are bound.
+func inputFields(inputType reflect.Type) ([]reflect.StructField, error) {
+	return appendInputFields(nil, inputType, nil, []reflect.Type{inputType})
+}
+
+// appendInputFields appends the fields of t to dst. prefix is the index path
+// of t and groups lists the struct types enclosing it, to reject cycles.
+func appendInputFields(dst []reflect.StructField, t reflect.Type, prefix []int, groups []reflect.Type) ([]reflect.StructField, error) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		field.Index = append(slices.Clip(prefix), i)
+
+		group, err := groupType(field)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			dst = append(dst, field)
+			continue
+		}
+		if slices.Contains(groups, group) {
+			return nil, fmt.Errorf("parameter group %q of type %s contains itself", field.Name, group)
+		}
+
+		dst, err = appendInputFields(dst, group, field.Index, append(slices.Clip(groups), group))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+// groupType returns the struct type whose fields field contributes when it is
+// a parameter group, or nil when field is bound (or skipped) on its own.
+func groupType(field reflect.StructField) (reflect.Type, error) {
+	jsonTag := field.Tag.Get("json")
+	if normalizedJSONTag(jsonTag) != "" {
+		return nil, nil
+	}
+	inline := parseTagOptions(jsonTag).Has("inline")
+	if !field.Anonymous && !inline {
+		return nil, nil
+	}
+
+	t := field.Type
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		if inline {
+			return nil, fmt.Errorf("inline field %q must be a struct or pointer to struct, got %s", field.Name, field.Type)
+		}
+		return nil, nil
+	}
+
+	// Fields promoted through an unexported embedded struct can still be
+	// set, but an unexported pointer cannot be allocated.
+	if !field.IsExported() && (inline || isPtr) {
+		return nil, fmt.Errorf("parameter group %q must be exported", field.Name)
+	}
+	return t, nil
+}
+
+// deepSource returns "query" or "form" when field decodes many keys of that
+// source: json:"query" and json:"form" bind the whole query or form, and the
+// deep option binds the keys under a prefix, as in json:"query:filter,deep".
+// It returns "" for every other field.
+func deepSource(field reflect.StructField, tag string, opts tagOptions) (string, error) {
+	if tag == "query" || tag == "form" {
+		return tag, nil
+	}
+	if !opts.Has("deep") {
+		return "", nil
+	}
+
+	source, name, _ := strings.Cut(tag, ":")
+	if source != "query" && source != "form" {
+		return "", fmt.Errorf("deep option on field %q is only supported for query and form tags", field.Name)
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s tag name cannot be empty for field %q", source, field.Name)
+	}
+	return source, nil
+}
+
+// csvSources lists the tag sources that accept the csv option.
+var csvSources = []string{"query", "header", "form"}
+
+// layoutSources lists the tag sources that accept the layout option.
+var layoutSources = []string{"query", "header", "path", "cookie", "form"}
+
+// defaultSources lists the tag sources that accept the default and required
+// options. Path parameters are always required.
+var defaultSources = []string{"query", "header", "cookie", "form"}
+
+// compileValueOptions translates the tag options of a string-based field into
+// resolver options, rejecting options that cannot apply to the field.
+//
+// Option values run to the next comma, so layouts containing commas must be
+// given by name, e.g. layout=RFC1123.
+func compileValueOptions(field reflect.StructField, tag string, opts tagOptions) (ValueOptions, error) {
+	source, _, _ := strings.Cut(tag, ":")
+	var valueOpts ValueOptions
+
+	if opts.Has("csv") {
+		if !slices.Contains(csvSources, source) {
+			return valueOpts, fmt.Errorf("csv option on field %q is only supported for query, header and form tags", field.Name)
+		}
+		if handlerResolvers.UnwrapOptional(field.Type).Kind() != reflect.Slice {
+			return valueOpts, fmt.Errorf("csv option on field %q requires a slice type, got %s", field.Name, field.Type)
+		}
+		valueOpts.CSV = true
+	}
+
+	if layout, ok := opts.Lookup("layout"); ok {
+		if !slices.Contains(layoutSources, source) {
+			return valueOpts, fmt.Errorf("layout option on field %q is only supported for query, header, path, cookie and form tags", field.Name)
+		}
+		if !handlerResolvers.IsTimeType(field.Type) {
+			return valueOpts, fmt.Errorf("layout option on field %q requires a time.Time type, got %s", field.Name, field.Type)
+		}
+		if layout == "" {
+			return valueOpts, fmt.Errorf("layout option on field %q cannot be empty", field.Name)
+		}
+		valueOpts.Layout = layout
+	}
+
+	defaultValue, hasDefault := opts.Lookup("default")
+	valueOpts.Required = opts.Has("required")
+	if hasDefault || valueOpts.Required {
+		if !slices.Contains(defaultSources, source) {
+			return valueOpts, fmt.Errorf("default and required options on field %q are only supported for query, header, cookie and form tags", field.Name)
+		}
+		if handlerResolvers.UnwrapOptional(field.Type) != field.Type {
+			return valueOpts, fmt.Errorf("default and required options on field %q cannot be used with Optional fields", field.Name)
+		}
+		if hasDefault && defaultValue == "" {
+			return valueOpts, fmt.Errorf("default option on field %q cannot be empty", field.Name)
+		}
+		valueOpts.Default = defaultValue
+		if err := valueOpts.Validate(field.Type); err != nil {
+			return valueOpts, fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
 
-	return resolvers, bodyFieldIdx, nil
+	return valueOpts, nil
+}
+
+// fileOptionNames lists the tag options that only apply to file fields.
+var fileOptionNames = []string{"maxsize", "types", "maxcount"}
+
+// compileFileOptions translates the maxsize, types and maxcount options of a
+// file field, e.g. json:"file:avatar,maxsize=2MB,types=image/png|image/jpeg".
+func compileFileOptions(field reflect.StructField, opts tagOptions) (FileOptions, error) {
+	var fileOpts FileOptions
+
+	if size, ok := opts.Lookup("maxsize"); ok {
+		n, err := handlerResolvers.ParseByteSize(size)
+		if err != nil {
+			return fileOpts, fmt.Errorf("maxsize option on field %q: %w", field.Name, err)
+		}
+		fileOpts.MaxSize = n
+	}
+
+	if types, ok := opts.Lookup("types"); ok {
+		if types == "" {
+			return fileOpts, fmt.Errorf("types option on field %q cannot be empty", field.Name)
+		}
+		fileOpts.Types = strings.Split(types, "|")
+	}
+
+	if count, ok := opts.Lookup("maxcount"); ok {
+		n, err := strconv.Atoi(count)
+		if err != nil || n <= 0 {
+			return fileOpts, fmt.Errorf("maxcount option on field %q must be a positive integer, got %q", field.Name, count)
+		}
+		fileOpts.MaxCount = n
+	}
+
+	if err := fileOpts.Validate(); err != nil {
+		return fileOpts, fmt.Errorf("field %q: %w", field.Name, err)
+	}
+	return fileOpts, nil
+}
+
+// tagOptions holds the comma-separated options that follow the first segment
+// of a json tag, e.g. "csv" in json:"query:ids,csv".
+type tagOptions []string
+
+// parseTagOptions returns the options of a json tag.
+func parseTagOptions(tag string) tagOptions {
+	_, rest, ok := strings.Cut(tag, ",")
+	if !ok {
+		return nil
+	}
+
+	var opts tagOptions
+	for _, opt := range strings.Split(rest, ",") {
+		if opt = strings.TrimSpace(opt); opt != "" {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
+
+// Has reports whether the option name is set.
+func (o tagOptions) Has(name string) bool {
+	return slices.Contains(o, name)
+}
+
+// Lookup returns the value of a name=value option.
+func (o tagOptions) Lookup(name string) (string, bool) {
+	for _, opt := range o {
+		if key, value, ok := strings.Cut(opt, "="); ok && key == name {
+			return value, true
+		}
+	}
+	return "", false
 }
 
 // normalizedJSONTag returns the first comma-delimited segment of a json tag.
@@ -103,13 +499,3 @@ func normalizedJSONTag(tag string) string {
 	parts := strings.Split(tag, ",")
 	return strings.TrimSpace(parts[0])
 }
-
-// resolverByFieldIndex returns resolver for a given struct field index.
-func resolverByFieldIndex(resolvers []FieldResolver, fieldIndex int) FieldResolver {
-	for _, resolver := range resolvers {
-		if resolver.FieldIndex() == fieldIndex {
-			return resolver
-		}
-	}
-	return nil
-}
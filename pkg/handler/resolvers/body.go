@@ -1,22 +1,34 @@
 package resolvers
 
 import (
-	"encoding/json"
 	"fmt"
 	"reflect"
 )
 
-// BodyResolver decodes request JSON body into a struct-typed field.
+// BodyResolver decodes the request body into a field, choosing a Decoder by
+// the request Content-Type.
 type BodyResolver struct {
 	fieldIdx  int
 	fieldType reflect.Type
+	decoders  map[string]Decoder
 }
 
 var _ FieldResolver = (*BodyResolver)(nil)
 
-// NewBodyResolver constructs a resolver for json:"body" fields.
+// NewBodyResolver constructs a resolver for json:"body" fields that accepts
+// JSON bodies only.
 func NewBodyResolver(fieldIdx int, fieldType reflect.Type) *BodyResolver {
-	return &BodyResolver{fieldIdx: fieldIdx, fieldType: fieldType}
+	return NewBodyResolverWithDecoders(fieldIdx, fieldType, nil)
+}
+
+// NewBodyResolverWithDecoders constructs a resolver for json:"body" fields
+// that decodes with the decoder registered for the request's media type.
+// A nil map accepts JSON only.
+func NewBodyResolverWithDecoders(fieldIdx int, fieldType reflect.Type, decoders map[string]Decoder) *BodyResolver {
+	if decoders == nil {
+		decoders = defaultDecoders
+	}
+	return &BodyResolver{fieldIdx: fieldIdx, fieldType: fieldType, decoders: decoders}
 }
 
 func (r *BodyResolver) FieldIndex() int { return r.fieldIdx }
@@ -26,17 +38,22 @@ func (r *BodyResolver) Resolve(ctx *Context) (reflect.Value, error) {
 		return reflect.Value{}, fmt.Errorf("request context is nil")
 	}
 
+	decoder, err := decoderFor(r.decoders, ctx.Request.Header.Get("Content-Type"))
+	if err != nil {
+		return reflect.Value{}, &ResolveError{Source: "body", Err: err}
+	}
+
 	if r.fieldType.Kind() == reflect.Ptr {
 		instance := reflect.New(r.fieldType.Elem())
-		if err := json.NewDecoder(ctx.Request.Body).Decode(instance.Interface()); err != nil {
-			return reflect.Value{}, fmt.Errorf("decode body: %w", err)
+		if err := decoder.Decode(ctx.Request.Body, instance.Interface()); err != nil {
+			return reflect.Value{}, &ResolveError{Source: "body", Err: err}
 		}
 		return instance, nil
 	}
 
 	instance := reflect.New(r.fieldType)
-	if err := json.NewDecoder(ctx.Request.Body).Decode(instance.Interface()); err != nil {
-		return reflect.Value{}, fmt.Errorf("decode body: %w", err)
+	if err := decoder.Decode(ctx.Request.Body, instance.Interface()); err != nil {
+		return reflect.Value{}, &ResolveError{Source: "body", Err: err}
 	}
 
 	return instance.Elem(), nil
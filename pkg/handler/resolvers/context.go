@@ -5,8 +5,12 @@ import "net/http"
 // Context carries request-scoped values used by field resolvers.
 //
 // Params is expected to be populated by a router for path-variable resolution.
-// When no router integration is present, Params may be empty.
+// When no router integration is present, Params may be empty and path
+// variables are read from http.Request.PathValue instead.
 type Context struct {
 	Request *http.Request
 	Params  map[string]string
+	// MultipartMemory is the number of bytes of a multipart form kept in
+	// memory; larger files are written to temporary files. Zero means 32 MB.
+	MultipartMemory int64
 }
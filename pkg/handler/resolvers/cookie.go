@@ -1,7 +1,9 @@
 package resolvers
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 )
 
@@ -10,13 +12,29 @@ type CookieResolver struct {
 	fieldIdx   int
 	cookieName string
 	fieldType  reflect.Type
+	convert    valuesConverter
+	// optional binds a nil pointer, an absent Optional or the default
+	// instead of failing on a missing cookie.
+	optional bool
 }
 
 var _ FieldResolver = (*CookieResolver)(nil)
 
 // NewCookieResolver constructs a resolver for json:"cookie:<name>" fields.
 func NewCookieResolver(fieldIdx int, cookieName string, fieldType reflect.Type) *CookieResolver {
-	return &CookieResolver{fieldIdx: fieldIdx, cookieName: cookieName, fieldType: fieldType}
+	return NewCookieResolverWithOptions(fieldIdx, cookieName, fieldType, ValueOptions{})
+}
+
+// NewCookieResolverWithOptions constructs a resolver for json:"cookie:<name>" fields
+// that converts values according to opts.
+func NewCookieResolverWithOptions(fieldIdx int, cookieName string, fieldType reflect.Type, opts ValueOptions) *CookieResolver {
+	return &CookieResolver{
+		fieldIdx:   fieldIdx,
+		cookieName: cookieName,
+		fieldType:  fieldType,
+		convert:    newValuesConverter(fieldType, opts),
+		optional:   opts.Default != "" || IsOptionalType(fieldType),
+	}
 }
 
 func (r *CookieResolver) FieldIndex() int { return r.fieldIdx }
@@ -27,13 +45,20 @@ func (r *CookieResolver) Resolve(ctx *Context) (reflect.Value, error) {
 	}
 
 	cookie, err := ctx.Request.Cookie(r.cookieName)
+	if errors.Is(err, http.ErrNoCookie) && r.optional {
+		value, _, err := r.convert(nil)
+		if err != nil {
+			return reflect.Value{}, &ResolveError{Source: "cookie", Name: r.cookieName, Err: err}
+		}
+		return value, nil
+	}
 	if err != nil {
-		return reflect.Value{}, fmt.Errorf("resolve cookie %q: %w", r.cookieName, err)
+		return reflect.Value{}, &ResolveError{Source: "cookie", Name: r.cookieName, Err: err}
 	}
 
-	value, err := convertStringToType(cookie.Value, r.fieldType)
+	value, _, err := r.convert([]string{cookie.Value})
 	if err != nil {
-		return reflect.Value{}, fmt.Errorf("resolve cookie %q: %w", r.cookieName, err)
+		return reflect.Value{}, &ResolveError{Source: "cookie", Name: r.cookieName, Raw: cookie.Value, Err: err}
 	}
 
 	return value, nil
@@ -0,0 +1,99 @@
+package resolvers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+)
+
+// ErrUnsupportedMediaType reports a request body whose Content-Type has no
+// registered Decoder.
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// Decoder decodes a request body into v, a pointer to the body field's type.
+type Decoder interface {
+	Decode(r io.Reader, v any) error
+}
+
+// DecoderFunc adapts a function to the Decoder interface.
+type DecoderFunc func(r io.Reader, v any) error
+
+func (f DecoderFunc) Decode(r io.Reader, v any) error { return f(r, v) }
+
+// errTrailingData reports bytes after the JSON value in a strict body.
+var errTrailingData = errors.New("unexpected data after JSON value")
+
+// JSONDecoder decodes bodies with encoding/json. The zero value behaves like
+// json.Decoder; the fields enable stricter decoding.
+type JSONDecoder struct {
+	// DisallowUnknownFields rejects object keys that match no field.
+	DisallowUnknownFields bool
+	// UseNumber decodes numbers into interface{} values as json.Number.
+	UseNumber bool
+	// DisallowTrailingData rejects anything but whitespace after the value.
+	DisallowTrailingData bool
+}
+
+func (d JSONDecoder) Decode(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	if d.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if d.UseNumber {
+		dec.UseNumber()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if !d.DisallowTrailingData {
+		return nil
+	}
+
+	_, err := dec.Token()
+	var syntaxErr *json.SyntaxError
+	switch {
+	case err == io.EOF:
+		return nil
+	case err == nil, errors.As(err, &syntaxErr):
+		return errTrailingData
+	default:
+		return err
+	}
+}
+
+// XMLDecoder decodes bodies with encoding/xml.
+type XMLDecoder struct{}
+
+func (XMLDecoder) Decode(r io.Reader, v any) error { return xml.NewDecoder(r).Decode(v) }
+
+// defaultDecoders is used by body resolvers constructed without a registry.
+var defaultDecoders = map[string]Decoder{"application/json": JSONDecoder{}}
+
+// decoderFor selects the decoder for a Content-Type header value.
+//
+// An empty Content-Type selects application/json. Structured syntax suffixes
+// fall back to their base type, so application/vnd.api+json uses the
+// application/json decoder unless one is registered for the full type.
+func decoderFor(decoders map[string]Decoder, contentType string) (Decoder, error) {
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedMediaType, contentType)
+	}
+
+	if d, ok := decoders[mediaType]; ok {
+		return d, nil
+	}
+	if idx := strings.LastIndexByte(mediaType, '+'); idx >= 0 {
+		if d, ok := decoders["application/"+mediaType[idx+1:]]; ok {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnsupportedMediaType, mediaType)
+}
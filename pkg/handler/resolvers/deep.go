@@ -0,0 +1,474 @@
+package resolvers
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DeepResolver decodes many query or form keys into one struct, map or slice
+// using bracket and dot notation:
+//
+//	filter[status]=open&filter.owner=me&sort[0][field]=created&tags[]=a
+//
+// With a prefix (json:"query:filter,deep") only the keys under it are
+// decoded; without one (json:"query") the whole query or form is. Struct
+// fields are keyed by their json tag name, or the Go field name when untagged.
+// Slice indexes only order the elements, so sort[5] after sort[0] binds a
+// slice of two.
+type DeepResolver struct {
+	fieldIdx  int
+	source    string
+	prefix    string
+	fieldType reflect.Type
+	decode    deepDecoder
+}
+
+var _ FieldResolver = (*DeepResolver)(nil)
+
+// NewDeepQueryResolver constructs a resolver for json:"query" and
+// json:"query:<prefix>,deep" fields. It fails when fieldType cannot be
+// decoded from request values.
+func NewDeepQueryResolver(fieldIdx int, prefix string, fieldType reflect.Type) (*DeepResolver, error) {
+	return newDeepResolver(fieldIdx, "query", prefix, fieldType)
+}
+
+// NewDeepFormResolver constructs a resolver for json:"form" and
+// json:"form:<prefix>,deep" fields. It fails when fieldType cannot be decoded
+// from request values.
+func NewDeepFormResolver(fieldIdx int, prefix string, fieldType reflect.Type) (*DeepResolver, error) {
+	return newDeepResolver(fieldIdx, "form", prefix, fieldType)
+}
+
+func newDeepResolver(fieldIdx int, source, prefix string, fieldType reflect.Type) (*DeepResolver, error) {
+	if fieldType == nil {
+		return nil, fmt.Errorf("field type is nil")
+	}
+	kind := derefKind(fieldType)
+	if isScalarType(fieldType) || (kind != reflect.Struct && kind != reflect.Map && kind != reflect.Slice) {
+		return nil, fmt.Errorf("deep binding requires a struct, map or slice, got %s", fieldType)
+	}
+	if prefix == "" && kind == reflect.Slice {
+		return nil, fmt.Errorf("deep binding of the whole %s requires a struct or map, got %s", source, fieldType)
+	}
+
+	decode, err := (&deepCompiler{decoders: map[reflect.Type]deepDecoder{}}).compile(fieldType)
+	if err != nil {
+		return nil, err
+	}
+	return &DeepResolver{fieldIdx: fieldIdx, source: source, prefix: prefix, fieldType: fieldType, decode: decode}, nil
+}
+
+func (r *DeepResolver) FieldIndex() int { return r.fieldIdx }
+
+func (r *DeepResolver) Resolve(ctx *Context) (reflect.Value, error) {
+	if ctx == nil || ctx.Request == nil {
+		return reflect.Value{}, fmt.Errorf("request context is nil")
+	}
+
+	var values url.Values
+	if r.source == "form" {
+		if err := parseForm(ctx); err != nil {
+			return reflect.Value{}, &ResolveError{Source: r.source, Name: r.prefix, Err: err}
+		}
+		values = ctx.Request.PostForm
+	} else {
+		values = ctx.Request.URL.Query()
+	}
+
+	node := newDeepTree(values)
+	if r.prefix != "" {
+		node = node.children[r.prefix]
+		if node == nil {
+			return reflect.Zero(r.fieldType), nil
+		}
+	}
+
+	value, err := r.decode(node, r.prefix)
+	if err != nil {
+		if de, ok := err.(*deepError); ok {
+			return reflect.Value{}, &ResolveError{Source: r.source, Name: de.key, Raw: de.raw, Err: de.err}
+		}
+		return reflect.Value{}, &ResolveError{Source: r.source, Name: r.prefix, Err: err}
+	}
+	return value, nil
+}
+
+// deepNode is one level of the key tree built from bracketed keys. values
+// holds the values sent for the key itself; children the keys nested in it,
+// in the order they were first seen.
+type deepNode struct {
+	values   []string
+	children map[string]*deepNode
+	keys     []string
+}
+
+// newDeepTree builds the key tree for values. Keys are visited in sorted
+// order so that element order does not depend on map iteration.
+func newDeepTree(values url.Values) *deepNode {
+	root := &deepNode{}
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		segments := splitDeepKey(key)
+
+		// An empty segment starts a new element, so every value of
+		// tags[]=a&tags[]=b or s[][a]=1&s[][a]=2 walks the key on its own and
+		// becomes an element of its own.
+		if slices.Contains(segments, "") {
+			for _, v := range values[key] {
+				root.walk(segments).values = []string{v}
+			}
+			continue
+		}
+		node := root.walk(segments)
+		node.values = append(node.values, values[key]...)
+	}
+	return root
+}
+
+// walk returns the node for segments below n, creating missing nodes.
+func (n *deepNode) walk(segments []string) *deepNode {
+	for _, segment := range segments {
+		n = n.child(segment)
+	}
+	return n
+}
+
+func (n *deepNode) child(key string) *deepNode {
+	if n.children == nil {
+		n.children = map[string]*deepNode{}
+	}
+	// An empty segment (tags[]=a) always appends a new element.
+	if c, ok := n.children[key]; ok && key != "" {
+		return c
+	}
+	c := &deepNode{}
+	if key == "" {
+		key = "\x00" + strconv.Itoa(len(n.keys))
+	}
+	n.children[key] = c
+	n.keys = append(n.keys, key)
+	return c
+}
+
+// splitDeepKey splits a[b][0].c into a, b, 0 and c. A key with an unbalanced
+// bracket is kept whole.
+func splitDeepKey(key string) []string {
+	head := strings.IndexAny(key, "[.")
+	if head <= 0 {
+		return []string{key}
+	}
+
+	segments := []string{key[:head]}
+	for rest := key[head:]; rest != ""; {
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return []string{key}
+			}
+			segments = append(segments, rest[1:end])
+			rest = rest[end+1:]
+		case '.':
+			end := strings.IndexAny(rest[1:], "[.")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			segments = append(segments, rest[1:end+1])
+			rest = rest[end+1:]
+		default:
+			return []string{key}
+		}
+	}
+	return segments
+}
+
+// deepError locates a conversion failure by its full key, e.g. filter[page].
+type deepError struct {
+	key string
+	raw string
+	err error
+}
+
+func (e *deepError) Error() string { return fmt.Sprintf("%s: %v", e.key, e.err) }
+
+// deepDecoder decodes node into a value; key is the node's full key, used to
+// report errors.
+type deepDecoder func(node *deepNode, key string) (reflect.Value, error)
+
+// deepCompiler compiles decoders once per type. decoders also breaks the
+// recursion of self-referencing types.
+type deepCompiler struct {
+	decoders map[reflect.Type]deepDecoder
+}
+
+func (c *deepCompiler) compile(t reflect.Type) (deepDecoder, error) {
+	if d, ok := c.decoders[t]; ok {
+		return d, nil
+	}
+
+	var decode deepDecoder
+	c.decoders[t] = func(node *deepNode, key string) (reflect.Value, error) { return decode(node, key) }
+
+	var err error
+	switch {
+	case isScalarType(t):
+		decode = c.scalar(t)
+	case UnwrapOptional(t) != t:
+		decode, err = c.optional(t)
+	case t.Kind() == reflect.Ptr:
+		decode, err = c.pointer(t)
+	case t.Kind() == reflect.Struct:
+		decode, err = c.structure(t)
+	case t.Kind() == reflect.Map:
+		decode, err = c.mapping(t)
+	case t.Kind() == reflect.Slice:
+		decode, err = c.slice(t)
+	default:
+		err = fmt.Errorf("unsupported field type %s", t)
+	}
+	if err != nil {
+		delete(c.decoders, t)
+		return nil, err
+	}
+	return decode, nil
+}
+
+func (c *deepCompiler) scalar(t reflect.Type) deepDecoder {
+	convert := newStringConverter(t, ValueOptions{})
+	return func(node *deepNode, key string) (reflect.Value, error) {
+		if len(node.keys) > 0 {
+			return reflect.Value{}, &deepError{key: key, err: fmt.Errorf("expected a value, got nested keys")}
+		}
+		if len(node.values) == 0 || node.values[0] == "" {
+			return reflect.Zero(t), nil
+		}
+		value, err := convert(node.values[0])
+		if err != nil {
+			return reflect.Value{}, &deepError{key: key, raw: node.values[0], err: err}
+		}
+		return value, nil
+	}
+}
+
+func (c *deepCompiler) pointer(t reflect.Type) (deepDecoder, error) {
+	decodeElem, err := c.compile(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+	return func(node *deepNode, key string) (reflect.Value, error) {
+		elem, err := decodeElem(node, key)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}, nil
+}
+
+// optional decodes an Optional[T] from the node T would decode from; Present
+// is set when the key was sent, even with an empty value.
+func (c *deepCompiler) optional(t reflect.Type) (deepDecoder, error) {
+	decodeValue, err := c.compile(UnwrapOptional(t))
+	if err != nil {
+		return nil, err
+	}
+	return func(node *deepNode, key string) (reflect.Value, error) {
+		value, err := decodeValue(node, key)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		opt := reflect.New(t).Elem()
+		opt.FieldByName("Value").Set(value)
+		opt.FieldByName("Present").SetBool(len(node.values) > 0 || len(node.keys) > 0)
+		return opt, nil
+	}, nil
+}
+
+// deepField is one decodable field of a struct.
+type deepField struct {
+	index  int
+	name   string
+	decode deepDecoder
+	// embedded fields decode from the enclosing node, like encoding/json.
+	embedded bool
+}
+
+func (c *deepCompiler) structure(t reflect.Type) (deepDecoder, error) {
+	var fields []deepField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		embedded := field.Anonymous && name == "" && derefKind(field.Type) == reflect.Struct && !isScalarType(field.Type)
+		if name == "" {
+			name = field.Name
+		}
+
+		decode, err := c.compile(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		fields = append(fields, deepField{index: i, name: name, decode: decode, embedded: embedded})
+	}
+
+	return func(node *deepNode, key string) (reflect.Value, error) {
+		value := reflect.New(t).Elem()
+		for _, f := range fields {
+			child, childKey := node, key
+			if !f.embedded {
+				if child = node.children[f.name]; child == nil {
+					continue
+				}
+				childKey = deepChildKey(key, f.name)
+			}
+
+			v, err := f.decode(child, childKey)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value.Field(f.index).Set(v)
+		}
+		return value, nil
+	}, nil
+}
+
+func (c *deepCompiler) mapping(t reflect.Type) (deepDecoder, error) {
+	if !isScalarType(t.Key()) {
+		return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+	}
+	convertKey := newStringConverter(t.Key(), ValueOptions{})
+	decodeElem, err := c.compile(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	return func(node *deepNode, key string) (reflect.Value, error) {
+		m := reflect.MakeMapWithSize(t, len(node.keys))
+		for _, name := range node.keys {
+			if strings.HasPrefix(name, "\x00") {
+				return reflect.Value{}, &deepError{key: key + "[]", err: fmt.Errorf("expected a named key")}
+			}
+			childKey := deepChildKey(key, name)
+			k, err := convertKey(name)
+			if err != nil {
+				return reflect.Value{}, &deepError{key: childKey, raw: name, err: fmt.Errorf("invalid key: %w", err)}
+			}
+			v, err := decodeElem(node.children[name], childKey)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			m.SetMapIndex(k, v)
+		}
+		return m, nil
+	}, nil
+}
+
+func (c *deepCompiler) slice(t reflect.Type) (deepDecoder, error) {
+	decodeElem, err := c.compile(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+	scalarElem := isScalarType(UnwrapOptional(t.Elem()))
+
+	return func(node *deepNode, key string) (reflect.Value, error) {
+		s := reflect.MakeSlice(t, 0, len(node.values)+len(node.keys))
+
+		// Repeated values (tags=a&tags=b) bind slices of scalars.
+		if scalarElem {
+			for _, raw := range node.values {
+				if raw == "" {
+					continue
+				}
+				v, err := decodeElem(&deepNode{values: []string{raw}}, key)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				s = reflect.Append(s, v)
+			}
+		}
+
+		type element struct {
+			order int
+			name  string
+		}
+		elements := make([]element, 0, len(node.keys))
+		for i, name := range node.keys {
+			if strings.HasPrefix(name, "\x00") {
+				elements = append(elements, element{order: i, name: name})
+				continue
+			}
+			n, err := strconv.Atoi(name)
+			if err != nil || n < 0 {
+				return reflect.Value{}, &deepError{key: deepChildKey(key, name), err: fmt.Errorf("invalid index %q", name)}
+			}
+			elements = append(elements, element{order: n, name: name})
+		}
+		slices.SortStableFunc(elements, func(a, b element) int { return a.order - b.order })
+
+		for _, e := range elements {
+			childKey := key + "[]"
+			if !strings.HasPrefix(e.name, "\x00") {
+				childKey = deepChildKey(key, e.name)
+			}
+			v, err := decodeElem(node.children[e.name], childKey)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			s = reflect.Append(s, v)
+		}
+		return s, nil
+	}, nil
+}
+
+// deepChildKey renders the key of a nested value in bracket notation.
+func deepChildKey(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "[" + name + "]"
+}
+
+// isScalarType reports whether values of t are converted from a single
+// string rather than decoded from nested keys.
+func isScalarType(t reflect.Type) bool {
+	if _, ok := registeredConverter(t); ok {
+		return true
+	}
+	if t == timeType || t == durationType {
+		return true
+	}
+	if t.Kind() != reflect.Ptr && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Ptr:
+		return isScalarType(t.Elem())
+	}
+	return false
+}
+
+// derefKind returns the kind of t after dereferencing pointers.
+func derefKind(t reflect.Type) reflect.Kind {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind()
+}
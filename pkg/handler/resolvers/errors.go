@@ -0,0 +1,36 @@
+package resolvers
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound reports that a required request value is absent.
+var ErrNotFound = errors.New("not found")
+
+// ResolveError reports a failure to resolve one field from the request.
+type ResolveError struct {
+	// Source is the tag source, e.g. "query" or "body".
+	Source string
+	// Name is the tag name, e.g. the query parameter name. Empty for body.
+	Name string
+	// Raw is the raw request value that failed conversion, if any.
+	Raw string
+	// Err is the underlying cause.
+	Err error
+}
+
+func (e *ResolveError) Error() string {
+	switch e.Source {
+	case "body":
+		return fmt.Sprintf("decode body: %v", e.Err)
+	case "multipart":
+		return fmt.Sprintf("read multipart body: %v", e.Err)
+	case "path":
+		return fmt.Sprintf("resolve path variable %q: %v", e.Name, e.Err)
+	default:
+		return fmt.Sprintf("resolve %s %q: %v", e.Source, e.Name, e.Err)
+	}
+}
+
+func (e *ResolveError) Unwrap() error { return e.Err }
@@ -1,33 +1,114 @@
 package resolvers
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"mime/multipart"
+	"net/http"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 )
 
-// defaultMaxMemory is the maximum bytes stored in memory for multipart parsing.
-// Files beyond this limit are written to temporary files on disk.
+// defaultMaxMemory is the maximum bytes stored in memory for multipart parsing
+// when Context.MultipartMemory is unset. Files beyond this limit are written
+// to temporary files on disk.
 const defaultMaxMemory = 32 << 20 // 32 MB
 
+// errNoMultipartData reports a request without a parsed multipart form.
+var errNoMultipartData = errors.New("no multipart form data")
+
+var (
+	// ErrFileTooLarge reports an upload over the MaxSize of its field.
+	ErrFileTooLarge = errors.New("file too large")
+	// ErrTooManyFiles reports more uploads than the MaxCount of their field.
+	ErrTooManyFiles = errors.New("too many files")
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// FileOptions constrains the files a FileResolver accepts. The zero value
+// accepts any file.
+type FileOptions struct {
+	// MaxSize rejects files larger than this many bytes with ErrFileTooLarge.
+	MaxSize int64
+	// Types lists the accepted media types, such as "image/png" or "image/*".
+	// The type is sniffed from the content with http.DetectContentType rather
+	// than taken from the client's Content-Type; a mismatch fails with
+	// ErrUnsupportedMediaType.
+	Types []string
+	// MaxCount rejects more than this many files with ErrTooManyFiles. It
+	// only applies to []*multipart.FileHeader fields.
+	MaxCount int
+}
+
+// Validate reports whether o is well formed.
+func (o FileOptions) Validate() error {
+	if o.MaxSize < 0 {
+		return fmt.Errorf("max size must not be negative, got %d", o.MaxSize)
+	}
+	if o.MaxCount < 0 {
+		return fmt.Errorf("max count must not be negative, got %d", o.MaxCount)
+	}
+	for _, t := range o.Types {
+		typ, subtype, ok := strings.Cut(t, "/")
+		if !ok || typ == "" || typ == "*" || subtype == "" || strings.ContainsAny(t, " ;,") {
+			return fmt.Errorf("invalid media type %q", t)
+		}
+	}
+	return nil
+}
+
 // MultipartFileHeaderType is the reflect.Type for *multipart.FileHeader.
 // Exported so the resolver compiler can validate field types at startup.
 var MultipartFileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
 
-// FileResolver resolves an uploaded file from a multipart/form-data request.
+// MultipartFileHeadersType is the reflect.Type for []*multipart.FileHeader,
+// which binds every file uploaded under one name.
+var MultipartFileHeadersType = reflect.TypeOf([]*multipart.FileHeader(nil))
+
+// FileResolver resolves uploaded files from a multipart/form-data request.
 //
-// The field type must be *multipart.FileHeader. The resolver accesses the
-// parsed multipart form directly, avoiding an unnecessary file open.
+// The field type must be *multipart.FileHeader, which binds the first file
+// sent under the name, or []*multipart.FileHeader, which binds all of them.
+// The resolver accesses the parsed multipart form directly, avoiding an
+// unnecessary file open.
 type FileResolver struct {
 	fieldIdx int
 	fileName string
+	multiple bool
+	opts     FileOptions
 }
 
 var _ FieldResolver = (*FileResolver)(nil)
 
-// NewFileResolver constructs a resolver for json:"file:<name>" fields.
+// NewFileResolver constructs a resolver for json:"file:<name>" fields of type
+// *multipart.FileHeader.
 func NewFileResolver(fieldIdx int, fileName string) *FileResolver {
-	return &FileResolver{fieldIdx: fieldIdx, fileName: fileName}
+	return NewFileResolverWithOptions(fieldIdx, fileName, FileOptions{})
+}
+
+// NewFileResolverWithOptions constructs a resolver for json:"file:<name>"
+// fields of type *multipart.FileHeader that enforces opts.
+func NewFileResolverWithOptions(fieldIdx int, fileName string, opts FileOptions) *FileResolver {
+	return &FileResolver{fieldIdx: fieldIdx, fileName: fileName, opts: opts}
+}
+
+// NewFileListResolver constructs a resolver for json:"file:<name>" fields of
+// type []*multipart.FileHeader.
+func NewFileListResolver(fieldIdx int, fileName string) *FileResolver {
+	return NewFileListResolverWithOptions(fieldIdx, fileName, FileOptions{})
+}
+
+// NewFileListResolverWithOptions constructs a resolver for
+// json:"file:<name>" fields of type []*multipart.FileHeader that enforces
+// opts.
+func NewFileListResolverWithOptions(fieldIdx int, fileName string, opts FileOptions) *FileResolver {
+	return &FileResolver{fieldIdx: fieldIdx, fileName: fileName, multiple: true, opts: opts}
 }
 
 func (r *FileResolver) FieldIndex() int { return r.fieldIdx }
@@ -37,18 +118,146 @@ func (r *FileResolver) Resolve(ctx *Context) (reflect.Value, error) {
 		return reflect.Value{}, fmt.Errorf("request context is nil")
 	}
 
-	if err := ctx.Request.ParseMultipartForm(defaultMaxMemory); err != nil {
-		return reflect.Value{}, fmt.Errorf("resolve file %q: %w", r.fileName, err)
+	if err := parseMultipartForm(ctx); err != nil {
+		return reflect.Value{}, &ResolveError{Source: "file", Name: r.fileName, Err: err}
 	}
 
 	if ctx.Request.MultipartForm == nil || ctx.Request.MultipartForm.File == nil {
-		return reflect.Value{}, fmt.Errorf("resolve file %q: no multipart form data", r.fileName)
+		return reflect.Value{}, &ResolveError{Source: "file", Name: r.fileName, Err: errNoMultipartData}
 	}
 
 	fhs := ctx.Request.MultipartForm.File[r.fileName]
 	if len(fhs) == 0 {
-		return reflect.Value{}, fmt.Errorf("resolve file %q: file not found", r.fileName)
+		return reflect.Value{}, &ResolveError{Source: "file", Name: r.fileName, Err: ErrNotFound}
 	}
 
+	if !r.multiple {
+		fhs = fhs[:1]
+	}
+	if err := r.check(fhs); err != nil {
+		return reflect.Value{}, err
+	}
+
+	if r.multiple {
+		return reflect.ValueOf(fhs), nil
+	}
 	return reflect.ValueOf(fhs[0]), nil
 }
+
+// check enforces the resolver's FileOptions on fhs: the count, then the size
+// and sniffed media type of each file.
+func (r *FileResolver) check(fhs []*multipart.FileHeader) error {
+	if r.opts.MaxCount > 0 && len(fhs) > r.opts.MaxCount {
+		return &ResolveError{Source: "file", Name: r.fileName, Raw: strconv.Itoa(len(fhs)), Err: &FormatError{
+			Expected: fmt.Sprintf("at most %d files", r.opts.MaxCount),
+			Err:      ErrTooManyFiles,
+		}}
+	}
+
+	for _, fh := range fhs {
+		if r.opts.MaxSize > 0 && fh.Size > r.opts.MaxSize {
+			return &ResolveError{Source: "file", Name: r.fileName, Raw: fh.Filename, Err: &FormatError{
+				Expected: "at most " + formatByteSize(r.opts.MaxSize),
+				Err:      fmt.Errorf("%w: %d bytes", ErrFileTooLarge, fh.Size),
+			}}
+		}
+
+		if len(r.opts.Types) == 0 {
+			continue
+		}
+		mediaType, err := sniffMediaType(fh)
+		if err != nil {
+			return &ResolveError{Source: "file", Name: r.fileName, Raw: fh.Filename, Err: err}
+		}
+		if !slices.ContainsFunc(r.opts.Types, func(t string) bool { return mediaTypeMatches(t, mediaType) }) {
+			return &ResolveError{Source: "file", Name: r.fileName, Raw: fh.Filename, Err: &FormatError{
+				Expected: strings.Join(r.opts.Types, " or "),
+				Err:      fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType),
+			}}
+		}
+	}
+	return nil
+}
+
+// sniffMediaType detects the media type of fh from its first bytes.
+func sniffMediaType(fh *multipart.FileHeader) (string, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	mediaType, _, _ := strings.Cut(http.DetectContentType(buf[:n]), ";")
+	return mediaType, nil
+}
+
+// mediaTypeMatches reports whether mediaType is accepted by pattern, which
+// may end in /* to accept a whole top-level type.
+func mediaTypeMatches(pattern, mediaType string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+	return strings.EqualFold(pattern, mediaType)
+}
+
+// formatByteSize renders n as the largest exact binary unit, e.g. 2MB for
+// 2 << 20; ParseByteSize reads the result back.
+func formatByteSize(n int64) string {
+	for _, unit := range byteUnits {
+		if n >= unit.size && n%unit.size == 0 {
+			return strconv.FormatInt(n/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(n, 10) + "B"
+}
+
+// ParseByteSize parses a size such as 512, 512B, 64KB, 2MB or 1GB. Units are
+// binary: 1KB is 1024 bytes.
+func ParseByteSize(s string) (int64, error) {
+	num, size := s, int64(1)
+	for _, unit := range byteUnits {
+		if trimmed, ok := strings.CutSuffix(strings.ToUpper(s), unit.suffix); ok {
+			num, size = trimmed, unit.size
+			break
+		}
+	}
+	num = strings.TrimSuffix(strings.ToUpper(num), "B")
+
+	n, err := strconv.ParseInt(strings.TrimSpace(num), 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/size {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * size, nil
+}
+
+// byteUnits lists the size suffixes from largest to smallest.
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+}
+
+// parseMultipartForm parses the request body with the memory limit of ctx.
+// It is a no-op once the form has been parsed.
+func parseMultipartForm(ctx *Context) error {
+	maxMemory := ctx.MultipartMemory
+	if maxMemory <= 0 {
+		maxMemory = defaultMaxMemory
+	}
+	return ctx.Request.ParseMultipartForm(maxMemory)
+}
+
+// isTooLarge reports whether err comes from a body over the size limit or a
+// multipart form whose non-file parts do not fit in memory.
+func isTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge) || errors.Is(err, multipart.ErrMessageTooLarge)
+}
@@ -7,20 +7,32 @@ import (
 
 // FormResolver resolves a form field value into the destination field type.
 //
-// It reads from the POST body only (not URL query parameters) using
+// It reads from the POST body only (not URL query parameters), like
 // request.PostFormValue. Works with both application/x-www-form-urlencoded
-// and multipart/form-data content types.
+// and multipart/form-data content types. Slice fields collect every value.
 type FormResolver struct {
 	fieldIdx  int
 	formName  string
 	fieldType reflect.Type
+	convert   valuesConverter
 }
 
 var _ FieldResolver = (*FormResolver)(nil)
 
 // NewFormResolver constructs a resolver for json:"form:<name>" fields.
 func NewFormResolver(fieldIdx int, formName string, fieldType reflect.Type) *FormResolver {
-	return &FormResolver{fieldIdx: fieldIdx, formName: formName, fieldType: fieldType}
+	return NewFormResolverWithOptions(fieldIdx, formName, fieldType, ValueOptions{})
+}
+
+// NewFormResolverWithOptions constructs a resolver for json:"form:<name>" fields
+// that converts values according to opts.
+func NewFormResolverWithOptions(fieldIdx int, formName string, fieldType reflect.Type, opts ValueOptions) *FormResolver {
+	return &FormResolver{
+		fieldIdx:  fieldIdx,
+		formName:  formName,
+		fieldType: fieldType,
+		convert:   newValuesConverter(fieldType, opts),
+	}
 }
 
 func (r *FormResolver) FieldIndex() int { return r.fieldIdx }
@@ -30,11 +42,24 @@ func (r *FormResolver) Resolve(ctx *Context) (reflect.Value, error) {
 		return reflect.Value{}, fmt.Errorf("request context is nil")
 	}
 
-	raw := ctx.Request.PostFormValue(r.formName)
-	value, err := convertStringToType(raw, r.fieldType)
+	if err := parseForm(ctx); err != nil {
+		return reflect.Value{}, &ResolveError{Source: "form", Name: r.formName, Err: err}
+	}
+
+	value, raw, err := r.convert(ctx.Request.PostForm[r.formName])
 	if err != nil {
-		return reflect.Value{}, fmt.Errorf("resolve form %q: %w", r.formName, err)
+		return reflect.Value{}, &ResolveError{Source: "form", Name: r.formName, Raw: raw, Err: err}
 	}
 
 	return value, nil
 }
+
+// parseForm parses the request body into PostForm. Like PostFormValue, it
+// ignores parse errors except an exceeded size limit, which must not be
+// mistaken for an absent field.
+func parseForm(ctx *Context) error {
+	if err := parseMultipartForm(ctx); err != nil && isTooLarge(err) {
+		return err
+	}
+	return nil
+}
@@ -6,17 +6,30 @@ import (
 )
 
 // HeaderResolver resolves a request header into the destination field type.
+// Slice fields collect the values of every header line.
 type HeaderResolver struct {
 	fieldIdx   int
 	headerName string
 	fieldType  reflect.Type
+	convert    valuesConverter
 }
 
 var _ FieldResolver = (*HeaderResolver)(nil)
 
 // NewHeaderResolver constructs a resolver for json:"header:<name>" fields.
 func NewHeaderResolver(fieldIdx int, headerName string, fieldType reflect.Type) *HeaderResolver {
-	return &HeaderResolver{fieldIdx: fieldIdx, headerName: headerName, fieldType: fieldType}
+	return NewHeaderResolverWithOptions(fieldIdx, headerName, fieldType, ValueOptions{})
+}
+
+// NewHeaderResolverWithOptions constructs a resolver for json:"header:<name>" fields
+// that converts values according to opts.
+func NewHeaderResolverWithOptions(fieldIdx int, headerName string, fieldType reflect.Type, opts ValueOptions) *HeaderResolver {
+	return &HeaderResolver{
+		fieldIdx:   fieldIdx,
+		headerName: headerName,
+		fieldType:  fieldType,
+		convert:    newValuesConverter(fieldType, opts),
+	}
 }
 
 func (r *HeaderResolver) FieldIndex() int { return r.fieldIdx }
@@ -26,10 +39,9 @@ func (r *HeaderResolver) Resolve(ctx *Context) (reflect.Value, error) {
 		return reflect.Value{}, fmt.Errorf("request context is nil")
 	}
 
-	raw := ctx.Request.Header.Get(r.headerName)
-	value, err := convertStringToType(raw, r.fieldType)
+	value, raw, err := r.convert(ctx.Request.Header.Values(r.headerName))
 	if err != nil {
-		return reflect.Value{}, fmt.Errorf("resolve header %q: %w", r.headerName, err)
+		return reflect.Value{}, &ResolveError{Source: "header", Name: r.headerName, Raw: raw, Err: err}
 	}
 
 	return value, nil
@@ -0,0 +1,80 @@
+package resolvers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// MultipartReaderType is the reflect.Type for *multipart.Reader.
+var MultipartReaderType = reflect.TypeOf((*multipart.Reader)(nil))
+
+// MultipartPartsType is the reflect.Type for iter.Seq2[*multipart.Part, error].
+var MultipartPartsType = reflect.TypeOf((iter.Seq2[*multipart.Part, error])(nil))
+
+// MultipartResolver hands a multipart request body to the handler as a
+// stream, so large uploads are never buffered in memory or on disk.
+//
+// The field type must be *multipart.Reader or
+// iter.Seq2[*multipart.Part, error]. The iterator yields each part in turn,
+// then stops; a read error is yielded once with a nil part. Each part must be
+// consumed before the next one is read.
+type MultipartResolver struct {
+	fieldIdx  int
+	fieldType reflect.Type
+}
+
+var _ FieldResolver = (*MultipartResolver)(nil)
+
+// NewMultipartResolver constructs a resolver for json:"multipart" fields. It
+// fails when fieldType is not one of the supported stream types.
+func NewMultipartResolver(fieldIdx int, fieldType reflect.Type) (*MultipartResolver, error) {
+	if fieldType != MultipartReaderType && fieldType != MultipartPartsType {
+		return nil, fmt.Errorf("multipart field must be %s or %s, got %s", MultipartReaderType, MultipartPartsType, fieldType)
+	}
+	return &MultipartResolver{fieldIdx: fieldIdx, fieldType: fieldType}, nil
+}
+
+func (r *MultipartResolver) FieldIndex() int { return r.fieldIdx }
+
+func (r *MultipartResolver) Resolve(ctx *Context) (reflect.Value, error) {
+	if ctx == nil || ctx.Request == nil {
+		return reflect.Value{}, fmt.Errorf("request context is nil")
+	}
+
+	mr, err := ctx.Request.MultipartReader()
+	if err != nil {
+		if errors.Is(err, http.ErrNotMultipart) {
+			err = fmt.Errorf("%w: %v", ErrUnsupportedMediaType, err)
+		}
+		return reflect.Value{}, &ResolveError{Source: "multipart", Err: err}
+	}
+
+	if r.fieldType == MultipartReaderType {
+		return reflect.ValueOf(mr), nil
+	}
+	return reflect.ValueOf(multipartParts(mr)), nil
+}
+
+// multipartParts iterates over the parts of mr.
+func multipartParts(mr *multipart.Reader) iter.Seq2[*multipart.Part, error] {
+	return func(yield func(*multipart.Part, error) bool) {
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(part, nil) {
+				return
+			}
+		}
+	}
+}
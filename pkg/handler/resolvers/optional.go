@@ -0,0 +1,48 @@
+package resolvers
+
+import "reflect"
+
+// Optional holds a request value together with whether the request sent it,
+// so a missing ?q differs from an explicit ?q= even though both convert to
+// the zero value. String-based resolvers set Present whenever the key is
+// sent, including with an empty value.
+type Optional[T any] struct {
+	Value   T
+	Present bool
+}
+
+// Get returns the value and whether it was present.
+func (o Optional[T]) Get() (T, bool) { return o.Value, o.Present }
+
+// OrElse returns the value if present and fallback otherwise.
+func (o Optional[T]) OrElse(fallback T) T {
+	if o.Present {
+		return o.Value
+	}
+	return fallback
+}
+
+// optional is implemented by every Optional[T] instantiation so that
+// resolvers can recognize them without knowing T.
+type optional interface {
+	optionalValueType() reflect.Type
+}
+
+func (Optional[T]) optionalValueType() reflect.Type { return reflect.TypeFor[T]() }
+
+// optionalInterface is used to detect Optional[T] field types.
+var optionalInterface = reflect.TypeOf((*optional)(nil)).Elem()
+
+// UnwrapOptional returns T for an Optional[T] type and t itself otherwise.
+func UnwrapOptional(t reflect.Type) reflect.Type {
+	if t != nil && t.Kind() == reflect.Struct && t.Implements(optionalInterface) {
+		return reflect.Zero(t).Interface().(optional).optionalValueType()
+	}
+	return t
+}
+
+// IsOptionalType reports whether missing values of t are representable:
+// t is a pointer or an Optional[T].
+func IsOptionalType(t reflect.Type) bool {
+	return t != nil && (t.Kind() == reflect.Ptr || UnwrapOptional(t) != t)
+}
@@ -3,20 +3,43 @@ package resolvers
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // PathVarResolver resolves a path parameter into the destination field type.
+//
+// Values come from Context.Params first; when a name is missing there, the
+// resolver falls back to http.Request.PathValue so handlers registered on a
+// Go 1.22+ http.ServeMux pattern such as "GET /users/{id}" work unchanged.
+// A wildcard declared by the matched pattern is present even when empty, as
+// with "/files/" matching "GET /files/{path...}".
+// A missing parameter is an error unless the field is a pointer or an
+// Optional[T].
 type PathVarResolver struct {
 	fieldIdx  int
 	paramName string
 	fieldType reflect.Type
+	convert   valuesConverter
+	optional  bool
 }
 
 var _ FieldResolver = (*PathVarResolver)(nil)
 
 // NewPathVarResolver constructs a resolver for json:"path:<name>" fields.
 func NewPathVarResolver(fieldIdx int, paramName string, fieldType reflect.Type) *PathVarResolver {
-	return &PathVarResolver{fieldIdx: fieldIdx, paramName: paramName, fieldType: fieldType}
+	return NewPathVarResolverWithOptions(fieldIdx, paramName, fieldType, ValueOptions{})
+}
+
+// NewPathVarResolverWithOptions constructs a resolver for json:"path:<name>" fields
+// that converts values according to opts.
+func NewPathVarResolverWithOptions(fieldIdx int, paramName string, fieldType reflect.Type, opts ValueOptions) *PathVarResolver {
+	return &PathVarResolver{
+		fieldIdx:  fieldIdx,
+		paramName: paramName,
+		fieldType: fieldType,
+		convert:   newValuesConverter(fieldType, opts),
+		optional:  IsOptionalType(fieldType),
+	}
 }
 
 func (r *PathVarResolver) FieldIndex() int { return r.fieldIdx }
@@ -28,13 +51,27 @@ func (r *PathVarResolver) Resolve(ctx *Context) (reflect.Value, error) {
 
 	raw, ok := ctx.Params[r.paramName]
 	if !ok {
-		return reflect.Value{}, fmt.Errorf("path variable %q not found", r.paramName)
+		raw = ctx.Request.PathValue(r.paramName)
+		ok = raw != "" || patternDeclares(ctx.Request.Pattern, r.paramName)
+	}
+	if !ok && !r.optional {
+		return reflect.Value{}, &ResolveError{Source: "path", Name: r.paramName, Err: ErrNotFound}
 	}
 
-	value, err := convertStringToType(raw, r.fieldType)
+	var raws []string
+	if ok {
+		raws = []string{raw}
+	}
+	value, _, err := r.convert(raws)
 	if err != nil {
-		return reflect.Value{}, fmt.Errorf("resolve path variable %q: %w", r.paramName, err)
+		return reflect.Value{}, &ResolveError{Source: "path", Name: r.paramName, Raw: raw, Err: err}
 	}
 
 	return value, nil
 }
+
+// patternDeclares reports whether the http.ServeMux pattern declares the
+// wildcard name, as {name} or {name...}.
+func patternDeclares(pattern, name string) bool {
+	return strings.Contains(pattern, "{"+name+"}") || strings.Contains(pattern, "{"+name+"...}")
+}
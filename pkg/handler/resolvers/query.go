@@ -6,17 +6,30 @@ import (
 )
 
 // QueryResolver resolves a query string value into the destination field type.
+// Slice fields collect every value of a repeated parameter.
 type QueryResolver struct {
 	fieldIdx  int
 	queryName string
 	fieldType reflect.Type
+	convert   valuesConverter
 }
 
 var _ FieldResolver = (*QueryResolver)(nil)
 
 // NewQueryResolver constructs a resolver for json:"query:<name>" fields.
 func NewQueryResolver(fieldIdx int, queryName string, fieldType reflect.Type) *QueryResolver {
-	return &QueryResolver{fieldIdx: fieldIdx, queryName: queryName, fieldType: fieldType}
+	return NewQueryResolverWithOptions(fieldIdx, queryName, fieldType, ValueOptions{})
+}
+
+// NewQueryResolverWithOptions constructs a resolver for json:"query:<name>" fields
+// that converts values according to opts.
+func NewQueryResolverWithOptions(fieldIdx int, queryName string, fieldType reflect.Type, opts ValueOptions) *QueryResolver {
+	return &QueryResolver{
+		fieldIdx:  fieldIdx,
+		queryName: queryName,
+		fieldType: fieldType,
+		convert:   newValuesConverter(fieldType, opts),
+	}
 }
 
 func (r *QueryResolver) FieldIndex() int { return r.fieldIdx }
@@ -26,10 +39,9 @@ func (r *QueryResolver) Resolve(ctx *Context) (reflect.Value, error) {
 		return reflect.Value{}, fmt.Errorf("request context is nil")
 	}
 
-	raw := ctx.Request.URL.Query().Get(r.queryName)
-	value, err := convertStringToType(raw, r.fieldType)
+	value, raw, err := r.convert(ctx.Request.URL.Query()[r.queryName])
 	if err != nil {
-		return reflect.Value{}, fmt.Errorf("resolve query %q: %w", r.queryName, err)
+		return reflect.Value{}, &ResolveError{Source: "query", Name: r.queryName, Raw: raw, Err: err}
 	}
 
 	return value, nil
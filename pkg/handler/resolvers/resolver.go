@@ -2,7 +2,8 @@ package resolvers
 
 import "reflect"
 
-// FieldIndexProvider exposes the target field index in the input struct.
+// FieldIndexProvider exposes the target field index in the input struct. For
+// a field of a parameter group it is the index within the group's struct.
 type FieldIndexProvider interface {
 	FieldIndex() int
 }
@@ -17,3 +18,14 @@ type FieldResolver interface {
 	FieldIndexProvider
 	FieldValueResolver
 }
+
+// BlockingResolver is implemented by resolvers that may block on I/O, such as
+// cache or auth lookups. Adapt runs resolvers reporting Blocking() == true
+// concurrently with each other and with the cheap, synchronous built-ins.
+//
+// Blocking resolvers must honour ctx.Request.Context() for cancellation and
+// must not read the request body.
+type BlockingResolver interface {
+	FieldResolver
+	Blocking() bool
+}
@@ -0,0 +1,102 @@
+package resolvers
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// FormatError reports a value that does not have the expected format.
+// Expected describes the format and is safe to show to clients.
+type FormatError struct {
+	Expected string
+	Err      error
+}
+
+func (e *FormatError) Error() string { return fmt.Sprintf("%v (expected %s)", e.Err, e.Expected) }
+func (e *FormatError) Unwrap() error { return e.Err }
+
+// namedLayouts maps layout names accepted by the layout tag option to time
+// layouts. "unix" and "unixmilli" are handled separately.
+var namedLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// IsTimeType reports whether t is time.Time, a pointer to it, a slice of
+// either or an Optional of those, i.e. a type the layout option applies to.
+func IsTimeType(t reflect.Type) bool {
+	t = UnwrapOptional(t)
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == timeType
+}
+
+// newTimeConverter parses time.Time values with layout: a Go reference
+// layout, a name from namedLayouts, "unix" or "unixmilli". An empty layout
+// means RFC 3339.
+func newTimeConverter(layout string) stringConverter {
+	switch layout {
+	case "unix", "unixmilli":
+		expected := "Unix seconds"
+		toTime := func(n int64) time.Time { return time.Unix(n, 0).UTC() }
+		if layout == "unixmilli" {
+			expected = "Unix milliseconds"
+			toTime = func(n int64) time.Time { return time.UnixMilli(n).UTC() }
+		}
+		return func(raw string) (reflect.Value, error) {
+			n, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				return reflect.Value{}, &FormatError{Expected: expected, Err: err}
+			}
+			return reflect.ValueOf(toTime(n)), nil
+		}
+	}
+
+	expected := "an RFC 3339 timestamp such as 2006-01-02T15:04:05Z"
+	switch {
+	case layout == "":
+		layout = time.RFC3339
+	case namedLayouts[layout] != "":
+		expected = fmt.Sprintf("a time in %s format (%s)", layout, namedLayouts[layout])
+		layout = namedLayouts[layout]
+	default:
+		expected = fmt.Sprintf("a time in the layout %s", layout)
+	}
+	return func(raw string) (reflect.Value, error) {
+		t, err := time.Parse(layout, raw)
+		if err != nil {
+			return reflect.Value{}, &FormatError{Expected: expected, Err: err}
+		}
+		return reflect.ValueOf(t), nil
+	}
+}
+
+// convertDuration parses time.Duration values with time.ParseDuration.
+func convertDuration(raw string) (reflect.Value, error) {
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return reflect.Value{}, &FormatError{Expected: "a duration such as 1h30m or 250ms", Err: err}
+	}
+	return reflect.ValueOf(d), nil
+}
@@ -1,64 +1,148 @@
 package resolvers
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
 	"strconv"
+	"sync"
 )
 
-func convertStringToType(raw string, fieldType reflect.Type) (reflect.Value, error) {
-	if fieldType == nil {
-		return reflect.Value{}, fmt.Errorf("field type is nil")
+// textUnmarshalerType is used to detect types that parse themselves.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+var (
+	convertersMu sync.RWMutex
+	converters   = map[reflect.Type]func(string) (any, error){}
+)
+
+// RegisterConverter registers fn to convert raw request strings into values
+// of type t. Registered converters take precedence over
+// encoding.TextUnmarshaler and the built-in kinds.
+//
+// Converters are selected when resolvers are built, so register them before
+// adapting the handlers that use t, typically from an init function. fn must
+// return a value assignable to t; it is not called for empty values, which
+// bind the zero value. It panics if t or fn is nil.
+func RegisterConverter(t reflect.Type, fn func(string) (any, error)) {
+	if t == nil || fn == nil {
+		panic("resolvers: RegisterConverter called with nil type or function")
+	}
+
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	converters[t] = fn
+}
+
+// registeredConverter returns the converter registered for t, if any.
+func registeredConverter(t reflect.Type) (func(string) (any, error), bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	fn, ok := converters[t]
+	return fn, ok
+}
+
+// stringConverter converts one non-empty raw request value.
+type stringConverter func(raw string) (reflect.Value, error)
+
+// newStringConverter selects how raw strings become values of fieldType. It
+// runs once per field when the resolver is built, consulting registered
+// converters, then time.Time and time.Duration, then
+// encoding.TextUnmarshaler on T and *T, then the kind.
+func newStringConverter(fieldType reflect.Type, opts ValueOptions) stringConverter {
+	if fn, ok := registeredConverter(fieldType); ok {
+		return func(raw string) (reflect.Value, error) {
+			v, err := fn(raw)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if v == nil {
+				return reflect.Zero(fieldType), nil
+			}
+			value := reflect.ValueOf(v)
+			if !value.Type().AssignableTo(fieldType) {
+				return reflect.Value{}, fmt.Errorf("converter for %s returned %T", fieldType, v)
+			}
+			return value, nil
+		}
+	}
+
+	switch fieldType {
+	case timeType:
+		return newTimeConverter(opts.Layout)
+	case durationType:
+		return convertDuration
 	}
 
-	if raw == "" {
-		return reflect.Zero(fieldType), nil
+	if fieldType.Kind() != reflect.Ptr && reflect.PointerTo(fieldType).Implements(textUnmarshalerType) {
+		return func(raw string) (reflect.Value, error) {
+			ptr := reflect.New(fieldType)
+			if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+				return reflect.Value{}, err
+			}
+			return ptr.Elem(), nil
+		}
 	}
 
 	switch fieldType.Kind() {
 	case reflect.String:
-		return reflect.ValueOf(raw).Convert(fieldType), nil
+		return func(raw string) (reflect.Value, error) {
+			return reflect.ValueOf(raw).Convert(fieldType), nil
+		}
 	case reflect.Bool:
-		v, err := strconv.ParseBool(raw)
-		if err != nil {
-			return reflect.Value{}, err
+		return func(raw string) (reflect.Value, error) {
+			v, err := strconv.ParseBool(raw)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value := reflect.New(fieldType).Elem()
+			value.SetBool(v)
+			return value, nil
 		}
-		value := reflect.New(fieldType).Elem()
-		value.SetBool(v)
-		return value, nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		v, err := strconv.ParseInt(raw, 10, fieldType.Bits())
-		if err != nil {
-			return reflect.Value{}, err
+		return func(raw string) (reflect.Value, error) {
+			v, err := strconv.ParseInt(raw, 10, fieldType.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value := reflect.New(fieldType).Elem()
+			value.SetInt(v)
+			return value, nil
 		}
-		value := reflect.New(fieldType).Elem()
-		value.SetInt(v)
-		return value, nil
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		v, err := strconv.ParseUint(raw, 10, fieldType.Bits())
-		if err != nil {
-			return reflect.Value{}, err
+		return func(raw string) (reflect.Value, error) {
+			v, err := strconv.ParseUint(raw, 10, fieldType.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value := reflect.New(fieldType).Elem()
+			value.SetUint(v)
+			return value, nil
 		}
-		value := reflect.New(fieldType).Elem()
-		value.SetUint(v)
-		return value, nil
 	case reflect.Float32, reflect.Float64:
-		v, err := strconv.ParseFloat(raw, fieldType.Bits())
-		if err != nil {
-			return reflect.Value{}, err
+		return func(raw string) (reflect.Value, error) {
+			v, err := strconv.ParseFloat(raw, fieldType.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value := reflect.New(fieldType).Elem()
+			value.SetFloat(v)
+			return value, nil
 		}
-		value := reflect.New(fieldType).Elem()
-		value.SetFloat(v)
-		return value, nil
 	case reflect.Ptr:
-		innerValue, err := convertStringToType(raw, fieldType.Elem())
-		if err != nil {
-			return reflect.Value{}, err
+		convertElem := newStringConverter(fieldType.Elem(), opts)
+		return func(raw string) (reflect.Value, error) {
+			innerValue, err := convertElem(raw)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			ptrValue := reflect.New(fieldType.Elem())
+			ptrValue.Elem().Set(innerValue)
+			return ptrValue, nil
 		}
-		ptrValue := reflect.New(fieldType.Elem())
-		ptrValue.Elem().Set(innerValue)
-		return ptrValue, nil
 	default:
-		return reflect.Value{}, fmt.Errorf("unsupported field type %s", fieldType)
+		return func(string) (reflect.Value, error) {
+			return reflect.Value{}, fmt.Errorf("unsupported field type %s", fieldType)
+		}
 	}
 }
@@ -0,0 +1,142 @@
+package resolvers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ValueOptions tunes how string-based resolvers convert request values.
+// The zero value converts each value as-is.
+type ValueOptions struct {
+	// CSV splits every value of a slice field on commas, mirroring the
+	// OpenAPI explode=false style: ids=1,2&ids=3 binds []int{1, 2, 3}.
+	CSV bool
+	// Layout parses time.Time fields: a reference layout such as
+	// "2006-01-02", a layout constant name such as "RFC1123", or "unix" or
+	// "unixmilli" for epoch seconds or milliseconds. Empty means RFC 3339.
+	Layout string
+	// Default is converted and bound when the value is missing or empty.
+	Default string
+	// Required makes a missing or empty value fail with ErrNotFound.
+	Required bool
+}
+
+// Validate reports whether o can apply to fieldType, converting Default so
+// that a bad default fails at startup rather than on the first request.
+func (o ValueOptions) Validate(fieldType reflect.Type) error {
+	if o.Default == "" {
+		return nil
+	}
+	if o.Required {
+		return fmt.Errorf("default and required are mutually exclusive")
+	}
+	if _, _, err := newValuesConverter(fieldType, o)(nil); err != nil {
+		return fmt.Errorf("invalid default %q: %w", o.Default, err)
+	}
+	return nil
+}
+
+// valuesConverter converts every value sent for one request key. On failure
+// it also returns the raw value that could not be converted.
+type valuesConverter func(raws []string) (reflect.Value, string, error)
+
+// newValuesConverter compiles the conversion of request values to fieldType.
+//
+// Slice fields collect all values, skipping empty ones; any other field
+// converts the first value. When no non-empty value is present, opts.Default
+// is converted instead, opts.Required fails with ErrNotFound, and otherwise
+// the zero value is bound, except that pointer fields get a pointer to the
+// zero value when the key was sent empty. Optional[T] fields wrap the
+// conversion of T and record whether the key was sent at all.
+//
+// A nil raws slice means the key was absent from the request.
+func newValuesConverter(fieldType reflect.Type, opts ValueOptions) valuesConverter {
+	if fieldType == nil {
+		return func([]string) (reflect.Value, string, error) {
+			return reflect.Value{}, "", fmt.Errorf("field type is nil")
+		}
+	}
+
+	if valueType := UnwrapOptional(fieldType); valueType != fieldType {
+		convertValue := newValuesConverter(valueType, opts)
+		return func(raws []string) (reflect.Value, string, error) {
+			if len(raws) == 0 {
+				return reflect.Zero(fieldType), "", nil
+			}
+			value, raw, err := convertValue(raws)
+			if err != nil {
+				return reflect.Value{}, raw, err
+			}
+			opt := reflect.New(fieldType).Elem()
+			opt.FieldByName("Value").Set(value)
+			opt.FieldByName("Present").SetBool(true)
+			return opt, raw, nil
+		}
+	}
+
+	convert := convertPresentValues(fieldType, opts)
+	defaults := []string{opts.Default}
+	return func(raws []string) (reflect.Value, string, error) {
+		value, raw, present, err := convert(raws)
+		switch {
+		case err != nil || present:
+			return value, raw, err
+		case opts.Required:
+			return reflect.Value{}, "", ErrNotFound
+		case opts.Default != "":
+			value, raw, _, err = convert(defaults)
+			return value, raw, err
+		case len(raws) > 0 && fieldType.Kind() == reflect.Ptr:
+			return reflect.New(fieldType.Elem()), "", nil
+		}
+		return reflect.Zero(fieldType), "", nil
+	}
+}
+
+// presentValuesConverter converts the values of a request key and reports
+// whether any non-empty value was present.
+type presentValuesConverter func(raws []string) (value reflect.Value, raw string, present bool, err error)
+
+// convertPresentValues compiles the conversion of the values actually sent.
+func convertPresentValues(fieldType reflect.Type, opts ValueOptions) presentValuesConverter {
+	if fieldType.Kind() != reflect.Slice {
+		convert := newStringConverter(fieldType, opts)
+		return func(raws []string) (reflect.Value, string, bool, error) {
+			if len(raws) == 0 || raws[0] == "" {
+				return reflect.Value{}, "", false, nil
+			}
+			value, err := convert(raws[0])
+			return value, raws[0], true, err
+		}
+	}
+
+	convertElem := newStringConverter(fieldType.Elem(), opts)
+	return func(raws []string) (reflect.Value, string, bool, error) {
+		items := raws
+		if opts.CSV {
+			items = nil
+			for _, raw := range raws {
+				for _, item := range strings.Split(raw, ",") {
+					items = append(items, strings.TrimSpace(item))
+				}
+			}
+		}
+
+		var slice reflect.Value
+		for _, item := range items {
+			if item == "" {
+				continue
+			}
+			elem, err := convertElem(item)
+			if err != nil {
+				return reflect.Value{}, item, true, err
+			}
+			if !slice.IsValid() {
+				slice = reflect.MakeSlice(fieldType, 0, len(items))
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		return slice, "", slice.IsValid(), nil
+	}
+}
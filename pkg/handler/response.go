@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// Response lets a handler choose the status code, headers and cookies written
+// alongside its body, which is encoded by the negotiated Renderer:
+//
+//	func CreateUser(req CreateUserInput) (handler.Response[*User], error) {
+//		user := ...
+//		return handler.Response[*User]{
+//			Status: http.StatusCreated,
+//			Header: http.Header{"Location": {"/users/" + user.ID}},
+//			Body:   user,
+//		}, nil
+//	}
+//
+// A zero Status means 200; other values must be in the 2xx-5xx range. The
+// body is not written for 204 and 304 responses.
+type Response[T any] struct {
+	Status  int
+	Header  http.Header
+	Cookies []*http.Cookie
+	Body    T
+}
+
+// responder is implemented by every Response[T] instantiation so that Adapt
+// can recognize them without knowing T.
+type responder interface {
+	responseParts() (status int, header http.Header, cookies []*http.Cookie, body any)
+}
+
+func (r Response[T]) responseParts() (int, http.Header, []*http.Cookie, any) {
+	return r.Status, r.Header, r.Cookies, r.Body
+}
+
+// responderInterface is used to detect Response[T] return types.
+var responderInterface = reflect.TypeOf((*responder)(nil)).Elem()
+
+// isResponseType reports whether t is an instantiation of Response[T].
+func isResponseType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(responderInterface)
+}
+
+// writeResult writes a handler's first non-error result with renderer.
+//
+// The body is encoded before anything is written, so an encoding failure can
+// still be reported as an error response.
+func writeResult(w http.ResponseWriter, renderer *mediaRenderer, result any) error {
+	status := http.StatusOK
+	body := result
+	var header http.Header
+	var cookies []*http.Cookie
+	if resp, ok := result.(responder); ok {
+		status, header, cookies, body = resp.responseParts()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if status < 200 || status > 599 {
+			return fmt.Errorf("invalid response status %d", status)
+		}
+	}
+
+	var payload []byte
+	if bodyAllowed(status) {
+		var buf bytes.Buffer
+		if err := renderer.renderer.Render(&buf, body); err != nil {
+			return err
+		}
+		payload = buf.Bytes()
+	}
+
+	for key, values := range header {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	for _, cookie := range cookies {
+		http.SetCookie(w, cookie)
+	}
+	if payload != nil && header.Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", renderer.mediaType)
+	}
+
+	w.WriteHeader(status)
+	_, _ = w.Write(payload)
+	return nil
+}
+
+// bodyAllowed reports whether a response with status may carry a body.
+func bodyAllowed(status int) bool {
+	return status != http.StatusNoContent && status != http.StatusNotModified
+}
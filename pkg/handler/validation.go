@@ -0,0 +1,526 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	handlerResolvers "github.com/sohamratnaparkhi/go-fast/pkg/handler/resolvers"
+)
+
+// FieldError describes one input field that failed validation.
+type FieldError struct {
+	// Field is the public name of the field: the tag name for header, query,
+	// path, cookie and form fields, or a dotted JSON path inside the body.
+	Field string `json:"field"`
+	// Source is the request source the field was bound from, e.g. "query".
+	Source string `json:"source"`
+	// Rule is the validate rule that failed, e.g. "min".
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors lists every field that failed validation. Adapt responds
+// with 422 Unprocessable Entity when validation fails.
+type ValidationErrors []FieldError
+
+var _ HTTPError = ValidationErrors(nil)
+
+func (e ValidationErrors) StatusCode() int       { return http.StatusUnprocessableEntity }
+func (e ValidationErrors) PublicMessage() string { return "validation failed" }
+func (e ValidationErrors) Details() any          { return nil }
+func (e ValidationErrors) Headers() http.Header  { return nil }
+func (e ValidationErrors) fieldErrors(bool) any  { return []FieldError(e) }
+
+func (e ValidationErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, fe := range e {
+		parts[i] = fmt.Sprintf("%s %s", fe.Field, fe.Message)
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// structValidator holds the compiled validate rules for one struct type.
+type structValidator struct {
+	fields []*fieldValidator
+}
+
+// fieldValidator validates one struct field and, for struct-typed fields, recurses.
+type fieldValidator struct {
+	// index is the path to the field, which crosses parameter groups for
+	// top-level fields.
+	index  []int
+	name   string
+	source string
+	rules  []validationRule
+
+	// nested validates struct (or *struct) values; elem validates the struct
+	// elements of slices and arrays.
+	nested *structValidator
+	elem   *structValidator
+	// flatten names nested fields without this field's name as a prefix; it
+	// is set for the body field so payload errors read "address.city".
+	flatten bool
+	// optional marks an Optional[T] field, whose rules apply to Value.
+	optional bool
+}
+
+// validationRule checks one rule against a dereferenced, non-nil value.
+type validationRule struct {
+	name  string
+	check func(v reflect.Value) (string, bool)
+}
+
+// compileValidator builds the validator for inputType from validate tags.
+//
+// Top-level fields, including those of parameter groups, are named after their
+// resolver tag; the json:"body" field and deep query and form fields are
+// walked recursively so nested request payloads are validated as well. It
+// returns nil when no field in the tree carries a validate tag.
+func compileValidator(inputType reflect.Type) (*structValidator, error) {
+	inputs, err := inputFields(inputType)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &validatorCompiler{structs: map[nestedKey]*structValidator{}}
+	sv := &structValidator{}
+	for _, field := range inputs {
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := normalizedJSONTag(field.Tag.Get("json"))
+		source, name, _ := strings.Cut(tag, ":")
+		switch {
+		case source == "body":
+			name = "body"
+		case name == "":
+			name = field.Name
+		}
+
+		// Deep query and form fields nest like the body; the resolver compiler
+		// has already rejected invalid deep tags.
+		deep, _ := deepSource(field, tag, parseTagOptions(field.Tag.Get("json")))
+		whole := source == "body" || (deep != "" && !strings.Contains(tag, ":"))
+
+		fv, err := c.compileFieldValidator(field, field.Index, name, source, source == "body" || deep != "")
+		if err != nil {
+			return nil, err
+		}
+		if fv != nil {
+			fv.flatten = whole
+			sv.fields = append(sv.fields, fv)
+		}
+	}
+
+	if len(sv.fields) == 0 {
+		return nil, nil
+	}
+	return sv, nil
+}
+
+// validatorCompiler compiles each nested struct type once per source. The
+// cache also ends recursion through self-referential payload types, such as a
+// comment with []Comment replies, whose validators refer back to themselves.
+type validatorCompiler struct {
+	structs map[nestedKey]*structValidator
+}
+
+type nestedKey struct {
+	t      reflect.Type
+	source string
+}
+
+// compileNestedValidator builds the validator for a struct nested in a body.
+// It returns nil when no field reachable from t carries a validate tag.
+func (c *validatorCompiler) compileNestedValidator(t reflect.Type, source string) (*structValidator, error) {
+	key := nestedKey{t: t, source: source}
+	if sv, ok := c.structs[key]; ok {
+		return sv, nil
+	}
+	if !hasValidateTags(t, map[reflect.Type]bool{}) {
+		c.structs[key] = nil
+		return nil, nil
+	}
+
+	// Register the validator before compiling its fields so that recursive
+	// references resolve to it.
+	sv := &structValidator{}
+	c.structs[key] = sv
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := normalizedJSONTag(field.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fv, err := c.compileFieldValidator(field, []int{i}, name, source, true)
+		if err != nil {
+			return nil, err
+		}
+		if fv != nil {
+			sv.fields = append(sv.fields, fv)
+		}
+	}
+	return sv, nil
+}
+
+// compileFieldValidator parses field's validate tag and, when recurse is set,
+// compiles validators for nested structs. It returns nil if nothing to check.
+func (c *validatorCompiler) compileFieldValidator(field reflect.StructField, index []int, name, source string, recurse bool) (*fieldValidator, error) {
+	fv := &fieldValidator{
+		index:    index,
+		name:     name,
+		source:   source,
+		optional: handlerResolvers.UnwrapOptional(field.Type) != field.Type,
+	}
+
+	if tag := validateTag(field); tag != "" {
+		rules, err := parseValidateTag(tag, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		fv.rules = rules
+	}
+
+	if recurse {
+		if st, elem, ok := nestedStruct(field.Type); ok {
+			sv, err := c.compileNestedValidator(st, source)
+			if err != nil {
+				return nil, err
+			}
+			if elem {
+				fv.elem = sv
+			} else {
+				fv.nested = sv
+			}
+		}
+	}
+
+	if len(fv.rules) == 0 && fv.nested == nil && fv.elem == nil {
+		return nil, nil
+	}
+	return fv, nil
+}
+
+// validateTag returns field's validate tag, or "" when it has none.
+func validateTag(field reflect.StructField) string {
+	tag := strings.TrimSpace(field.Tag.Get("validate"))
+	if tag == "-" {
+		return ""
+	}
+	return tag
+}
+
+// nestedStruct returns the struct type validated inside a field of type t:
+// t, its pointee or its Optional value for structs, or the element type for
+// slices and arrays of structs, in which case elem is set.
+func nestedStruct(t reflect.Type) (st reflect.Type, elem, ok bool) {
+	t = handlerResolvers.UnwrapOptional(t)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t.Kind() == reflect.Struct:
+		return t, false, true
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && derefType(t.Elem()).Kind() == reflect.Struct:
+		return derefType(t.Elem()), true, true
+	}
+	return nil, false, false
+}
+
+// hasValidateTags reports whether any field reachable from the struct type t
+// carries a validate tag; visited guards against recursive types.
+func hasValidateTags(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || normalizedJSONTag(field.Tag.Get("json")) == "-" {
+			continue
+		}
+		if validateTag(field) != "" {
+			return true
+		}
+		if st, _, ok := nestedStruct(field.Type); ok && hasValidateTags(st, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// validate appends every failure found in v (a struct value) to errs.
+func (sv *structValidator) validate(v reflect.Value, prefix string, errs *ValidationErrors) {
+	for _, fv := range sv.fields {
+		// The only nil pointers on the path are parameter groups with no
+		// bound fields, which have nothing to validate.
+		field, err := v.FieldByIndexErr(fv.index)
+		if err != nil {
+			continue
+		}
+		fv.validate(field, prefix, errs)
+	}
+}
+
+func (fv *fieldValidator) validate(v reflect.Value, prefix string, errs *ValidationErrors) {
+	name := prefix + fv.name
+
+	// An Optional that was not sent is treated like a nil pointer; one that
+	// was sent satisfies required even when its value is empty.
+	absent := false
+	if fv.optional {
+		absent = !v.FieldByName("Present").Bool()
+		v = v.FieldByName("Value")
+	}
+
+	isNil := absent || (v.Kind() == reflect.Ptr && v.IsNil())
+	for _, rule := range fv.rules {
+		if rule.name == "required" {
+			if isNil || (!fv.optional && v.IsZero()) {
+				*errs = append(*errs, FieldError{Field: name, Source: fv.source, Rule: rule.name, Message: "is required"})
+				return
+			}
+			continue
+		}
+
+		if isNil {
+			continue
+		}
+		if msg, ok := rule.check(reflect.Indirect(v)); !ok {
+			*errs = append(*errs, FieldError{Field: name, Source: fv.source, Rule: rule.name, Message: msg})
+		}
+	}
+
+	if isNil {
+		return
+	}
+	v = reflect.Indirect(v)
+
+	nestedPrefix := name + "."
+	if fv.flatten {
+		nestedPrefix = prefix
+	}
+	if fv.nested != nil {
+		fv.nested.validate(v, nestedPrefix, errs)
+	}
+	if fv.elem != nil {
+		for i := 0; i < v.Len(); i++ {
+			elem := v.Index(i)
+			if elem.Kind() == reflect.Ptr {
+				if elem.IsNil() {
+					continue
+				}
+				elem = elem.Elem()
+			}
+			fv.elem.validate(elem, fmt.Sprintf("%s[%d].", strings.TrimSuffix(nestedPrefix, "."), i), errs)
+		}
+	}
+}
+
+// parseValidateTag compiles a comma-separated validate tag for fieldType; the
+// rules of an Optional[T] field are compiled for T. pattern takes the rest of the tag as its argument, so that its regular
+// expression may contain commas; it must therefore be the last rule.
+func parseValidateTag(tag string, fieldType reflect.Type) ([]validationRule, error) {
+	t := derefType(handlerResolvers.UnwrapOptional(fieldType))
+
+	var rules []validationRule
+	for rest := tag; rest != ""; {
+		var part string
+		part, rest, _ = strings.Cut(rest, ",")
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "pattern=") && rest != "" {
+			part, rest = part+","+rest, ""
+		}
+		if part == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(part, "=")
+
+		var check func(reflect.Value) (string, bool)
+		var err error
+		switch name {
+		case "required":
+		case "min", "max":
+			check, err = boundRule(name, arg, t)
+		case "len":
+			check, err = lenRule(arg, t)
+		case "pattern":
+			check, err = patternRule(arg, t)
+		case "oneof":
+			check, err = oneOfRule(arg, t)
+		case "email":
+			check, err = emailRule(t)
+		default:
+			err = fmt.Errorf("unknown validate rule %q", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, validationRule{name: name, check: check})
+	}
+
+	// required runs first so that a missing value yields a single error.
+	slices.SortStableFunc(rules, func(a, b validationRule) int {
+		switch {
+		case a.name == "required" && b.name != "required":
+			return -1
+		case b.name == "required" && a.name != "required":
+			return 1
+		}
+		return 0
+	})
+	return rules, nil
+}
+
+// boundRule implements min and max: numeric bounds for numbers, length
+// bounds for strings, slices, arrays and maps.
+func boundRule(name, arg string, t reflect.Type) (func(reflect.Value) (string, bool), error) {
+	atLeast := name == "min"
+	word := "at most"
+	if atLeast {
+		word = "at least"
+	}
+
+	if hasLength(t) {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s=%q: expected a non-negative integer", name, arg)
+		}
+		msg := fmt.Sprintf("length must be %s %d", word, n)
+		return func(v reflect.Value) (string, bool) {
+			if atLeast {
+				return msg, lengthOf(v) >= n
+			}
+			return msg, lengthOf(v) <= n
+		}, nil
+	}
+
+	if isNumber(t) {
+		bound, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s=%q: expected a number", name, arg)
+		}
+		msg := fmt.Sprintf("must be %s %s", word, arg)
+		return func(v reflect.Value) (string, bool) {
+			if atLeast {
+				return msg, numberOf(v) >= bound
+			}
+			return msg, numberOf(v) <= bound
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%s is not supported for type %s", name, t)
+}
+
+// lenRule requires an exact length for strings, slices, arrays and maps.
+func lenRule(arg string, t reflect.Type) (func(reflect.Value) (string, bool), error) {
+	if !hasLength(t) {
+		return nil, fmt.Errorf("len is not supported for type %s", t)
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("len=%q: expected a non-negative integer", arg)
+	}
+	msg := fmt.Sprintf("length must be %d", n)
+	return func(v reflect.Value) (string, bool) { return msg, lengthOf(v) == n }, nil
+}
+
+// patternRule requires a string to match a regular expression.
+func patternRule(arg string, t reflect.Type) (func(reflect.Value) (string, bool), error) {
+	if t.Kind() != reflect.String {
+		return nil, fmt.Errorf("pattern is not supported for type %s", t)
+	}
+	re, err := regexp.Compile(arg)
+	if err != nil {
+		return nil, fmt.Errorf("pattern=%q: %w", arg, err)
+	}
+	msg := fmt.Sprintf("must match pattern %s", arg)
+	return func(v reflect.Value) (string, bool) { return msg, re.MatchString(v.String()) }, nil
+}
+
+// oneOfRule requires a string or number to equal one of the space-separated options.
+func oneOfRule(arg string, t reflect.Type) (func(reflect.Value) (string, bool), error) {
+	if t.Kind() != reflect.String && !isNumber(t) {
+		return nil, fmt.Errorf("oneof is not supported for type %s", t)
+	}
+	options := strings.Fields(arg)
+	if len(options) == 0 {
+		return nil, fmt.Errorf("oneof requires at least one option")
+	}
+	msg := fmt.Sprintf("must be one of [%s]", strings.Join(options, " "))
+	return func(v reflect.Value) (string, bool) {
+		return msg, slices.Contains(options, fmt.Sprint(v.Interface()))
+	}, nil
+}
+
+// emailRule requires a string to be a bare RFC 5322 address.
+func emailRule(t reflect.Type) (func(reflect.Value) (string, bool), error) {
+	if t.Kind() != reflect.String {
+		return nil, fmt.Errorf("email is not supported for type %s", t)
+	}
+	const msg = "must be a valid email address"
+	return func(v reflect.Value) (string, bool) {
+		addr, err := mail.ParseAddress(v.String())
+		return msg, err == nil && addr.Address == v.String()
+	}, nil
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func hasLength(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+func lengthOf(v reflect.Value) int {
+	if v.Kind() == reflect.String {
+		return len([]rune(v.String()))
+	}
+	return v.Len()
+}
+
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func numberOf(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
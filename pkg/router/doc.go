@@ -0,0 +1,6 @@
+// Package router provides the radix-tree HTTP router used by go-fast.
+//
+// It matches static, ":param" and "*catchall" segments, distinguishes 404 from
+// 405 responses, and passes extracted path parameters to handlers built by
+// handler.Adapt so json:"path:<name>" fields resolve without extra wiring.
+package router
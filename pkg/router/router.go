@@ -0,0 +1,202 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+// Router dispatches requests to adapted handlers using a radix tree.
+//
+// Routes are registered with user handler functions; the router calls
+// handler.Adapt at registration time and threads extracted path parameters
+// into the resolver Context at request time.
+//
+// Router embeds the root RouterGroup, so routes, middleware and sub-groups can be
+// registered on it directly.
+type Router struct {
+	RouterGroup
+
+	root *node
+
+	// NotFound handles requests whose path matches no route.
+	// When nil, http.NotFound is used.
+	NotFound http.Handler
+
+	// MethodNotAllowed handles requests whose path matches a route that is not
+	// registered for the request method. The Allow header is set before it runs.
+	// When nil, a plain 405 response is written.
+	MethodNotAllowed http.Handler
+}
+
+var _ http.Handler = (*Router)(nil)
+
+// New returns an empty Router. The given options are applied to every route
+// adapted through it, before group and per-route options.
+func New(opts ...handler.Option) *Router {
+	r := &Router{root: &node{kind: staticNode}}
+	r.RouterGroup = RouterGroup{router: r, opts: append([]handler.Option(nil), opts...)}
+	return r
+}
+
+// RouterGroup is a set of routes sharing a path prefix, handler options and
+// middleware.
+type RouterGroup struct {
+	router *Router
+	prefix string
+	opts   []handler.Option
+}
+
+// Group returns a sub-group rooted at prefix. The sub-group inherits the
+// options and middleware registered on g so far, followed by opts.
+func (g *RouterGroup) Group(prefix string, opts ...handler.Option) *RouterGroup {
+	inherited := make([]handler.Option, 0, len(g.opts)+len(opts))
+	inherited = append(inherited, g.opts...)
+	inherited = append(inherited, opts...)
+	return &RouterGroup{router: g.router, prefix: g.prefix + strings.TrimSuffix(prefix, "/"), opts: inherited}
+}
+
+// Use appends middleware to g.
+//
+// Middleware is bound when a route is registered, so Use affects routes and
+// sub-groups created after the call. For every route the chain runs global
+// middleware first, then each enclosing group's from outermost to innermost,
+// then per-route middleware, each in registration order.
+func (g *RouterGroup) Use(mw ...handler.Middleware) {
+	g.opts = append(g.opts, handler.WithMiddleware(mw...))
+}
+
+// Handle adapts fn and registers it for method and pattern.
+//
+// Patterns are absolute paths that may contain ":name" segments, which match
+// exactly one path segment, and a trailing "*name" segment, which matches the
+// rest of the path. Both are exposed to json:"path:<name>" fields, and a path
+// tag that names no wildcard in pattern is rejected at registration.
+//
+// opts are applied after the router and group options, so per-route
+// middleware runs innermost.
+func (g *RouterGroup) Handle(method, pattern string, fn interface{}, opts ...handler.Option) error {
+	full := g.prefix + pattern
+
+	all := make([]handler.Option, 0, len(g.opts)+len(opts)+1)
+	all = append(all, g.opts...)
+	all = append(all, opts...)
+	all = append(all, handler.WithPattern(full))
+
+	h, err := handler.Adapt(fn, all...)
+	if err != nil {
+		return fmt.Errorf("route %s %s: %w", method, full, err)
+	}
+
+	return g.HandleHTTP(method, pattern, h)
+}
+
+// HandleHTTP registers a plain http.Handler for method and pattern.
+//
+// Path parameters are available to h through handler.ParamsFromRequest.
+// Middleware does not apply to plain handlers.
+func (g *RouterGroup) HandleHTTP(method, pattern string, h http.Handler) error {
+	full := g.prefix + pattern
+	if method == "" {
+		return fmt.Errorf("route %q: method cannot be empty", full)
+	}
+	if h == nil {
+		return fmt.Errorf("route %s %s: handler is nil", method, full)
+	}
+
+	segments, err := parsePattern(full)
+	if err != nil {
+		return fmt.Errorf("route %s %s: %w", method, full, err)
+	}
+
+	if err := g.router.root.insert(method, segments, h); err != nil {
+		return fmt.Errorf("route %s %s: %w", method, full, err)
+	}
+	return nil
+}
+
+// GET registers fn for GET requests. It panics if the route is invalid,
+// mirroring http.ServeMux, so misconfiguration fails at startup.
+func (g *RouterGroup) GET(pattern string, fn interface{}, opts ...handler.Option) {
+	g.mustHandle(http.MethodGet, pattern, fn, opts)
+}
+
+// HEAD registers fn for HEAD requests. It panics if the route is invalid.
+func (g *RouterGroup) HEAD(pattern string, fn interface{}, opts ...handler.Option) {
+	g.mustHandle(http.MethodHead, pattern, fn, opts)
+}
+
+// POST registers fn for POST requests. It panics if the route is invalid.
+func (g *RouterGroup) POST(pattern string, fn interface{}, opts ...handler.Option) {
+	g.mustHandle(http.MethodPost, pattern, fn, opts)
+}
+
+// PUT registers fn for PUT requests. It panics if the route is invalid.
+func (g *RouterGroup) PUT(pattern string, fn interface{}, opts ...handler.Option) {
+	g.mustHandle(http.MethodPut, pattern, fn, opts)
+}
+
+// PATCH registers fn for PATCH requests. It panics if the route is invalid.
+func (g *RouterGroup) PATCH(pattern string, fn interface{}, opts ...handler.Option) {
+	g.mustHandle(http.MethodPatch, pattern, fn, opts)
+}
+
+// DELETE registers fn for DELETE requests. It panics if the route is invalid.
+func (g *RouterGroup) DELETE(pattern string, fn interface{}, opts ...handler.Option) {
+	g.mustHandle(http.MethodDelete, pattern, fn, opts)
+}
+
+// OPTIONS registers fn for OPTIONS requests. It panics if the route is invalid.
+func (g *RouterGroup) OPTIONS(pattern string, fn interface{}, opts ...handler.Option) {
+	g.mustHandle(http.MethodOptions, pattern, fn, opts)
+}
+
+func (g *RouterGroup) mustHandle(method, pattern string, fn interface{}, opts []handler.Option) {
+	if err := g.Handle(method, pattern, fn, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// ServeHTTP dispatches the request to the handler registered for its path and method.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	n, params := r.root.lookup(req.URL.Path, nil)
+	if n == nil {
+		if r.NotFound != nil {
+			r.NotFound.ServeHTTP(w, req)
+			return
+		}
+		http.NotFound(w, req)
+		return
+	}
+
+	h, ok := n.handlers[req.Method]
+	if !ok {
+		w.Header().Set("Allow", allowedMethods(n))
+		if r.MethodNotAllowed != nil {
+			r.MethodNotAllowed.ServeHTTP(w, req)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	values := make(map[string]string, len(params))
+	for _, p := range params {
+		values[p.name] = p.value
+	}
+
+	h.ServeHTTP(w, handler.WithParams(req, values))
+}
+
+// allowedMethods returns the sorted, comma-separated methods registered on n.
+func allowedMethods(n *node) string {
+	methods := make([]string, 0, len(n.handlers))
+	for method := range n.handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
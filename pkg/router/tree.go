@@ -0,0 +1,233 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// nodeKind distinguishes how a tree node matches a portion of the request path.
+type nodeKind uint8
+
+const (
+	staticNode   nodeKind = iota // matches a literal prefix
+	paramNode                    // matches one non-empty path segment (":name")
+	catchAllNode                 // matches the remainder of the path ("*name")
+)
+
+// node is a vertex of the radix tree.
+//
+// Static children are compressed on shared prefixes so lookup cost is
+// proportional to the path length, not to the number of registered routes.
+// Handlers live on the node where a pattern ends, keyed by HTTP method, so a
+// single lookup can tell "no route" (404) apart from "wrong method" (405).
+type node struct {
+	kind   nodeKind
+	prefix string // literal text for static nodes
+	name   string // parameter name for param and catch-all nodes
+
+	static   []*node
+	param    *node
+	catchAll *node
+
+	handlers map[string]http.Handler
+}
+
+// param is one extracted path parameter.
+type param struct {
+	name  string
+	value string
+}
+
+// segment is one parsed piece of a route pattern.
+type segment struct {
+	kind  nodeKind
+	value string // literal text or parameter name
+}
+
+// parsePattern splits a route pattern into static, param and catch-all segments.
+func parsePattern(pattern string) ([]segment, error) {
+	if pattern == "" || pattern[0] != '/' {
+		return nil, fmt.Errorf("pattern %q must begin with '/'", pattern)
+	}
+
+	var segments []segment
+	seen := map[string]bool{}
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c != ':' && c != '*' {
+			end := strings.IndexAny(pattern[i:], ":*")
+			if end < 0 {
+				end = len(pattern)
+			} else {
+				end += i
+			}
+			segments = append(segments, segment{kind: staticNode, value: pattern[i:end]})
+			i = end
+			continue
+		}
+
+		if pattern[i-1] != '/' {
+			return nil, fmt.Errorf("pattern %q: wildcard must start a path segment", pattern)
+		}
+
+		end := strings.IndexByte(pattern[i:], '/')
+		if end < 0 {
+			end = len(pattern)
+		} else {
+			end += i
+		}
+
+		name := pattern[i+1 : end]
+		if name == "" {
+			return nil, fmt.Errorf("pattern %q: wildcard name cannot be empty", pattern)
+		}
+		if strings.ContainsAny(name, ":*") {
+			return nil, fmt.Errorf("pattern %q: only one wildcard per path segment is allowed", pattern)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("pattern %q: duplicate wildcard name %q", pattern, name)
+		}
+		seen[name] = true
+
+		if c == '*' {
+			if end != len(pattern) {
+				return nil, fmt.Errorf("pattern %q: catch-all %q must be the last segment", pattern, name)
+			}
+			segments = append(segments, segment{kind: catchAllNode, value: name})
+		} else {
+			segments = append(segments, segment{kind: paramNode, value: name})
+		}
+		i = end
+	}
+
+	return segments, nil
+}
+
+// insert registers h for method at the node described by segments.
+func (n *node) insert(method string, segments []segment, h http.Handler) error {
+	cur := n
+	for _, seg := range segments {
+		switch seg.kind {
+		case staticNode:
+			cur = cur.insertStatic(seg.value)
+
+		case paramNode:
+			if cur.catchAll != nil {
+				return fmt.Errorf("wildcard :%s conflicts with catch-all *%s", seg.value, cur.catchAll.name)
+			}
+			if cur.param == nil {
+				cur.param = &node{kind: paramNode, name: seg.value}
+			} else if cur.param.name != seg.value {
+				return fmt.Errorf("wildcard :%s conflicts with existing wildcard :%s", seg.value, cur.param.name)
+			}
+			cur = cur.param
+
+		case catchAllNode:
+			if cur.param != nil {
+				return fmt.Errorf("catch-all *%s conflicts with wildcard :%s", seg.value, cur.param.name)
+			}
+			if cur.catchAll == nil {
+				cur.catchAll = &node{kind: catchAllNode, name: seg.value}
+			} else if cur.catchAll.name != seg.value {
+				return fmt.Errorf("catch-all *%s conflicts with existing catch-all *%s", seg.value, cur.catchAll.name)
+			}
+			cur = cur.catchAll
+		}
+	}
+
+	if cur.handlers == nil {
+		cur.handlers = map[string]http.Handler{}
+	}
+	if _, exists := cur.handlers[method]; exists {
+		return fmt.Errorf("a handler is already registered for this method and path")
+	}
+	cur.handlers[method] = h
+	return nil
+}
+
+// insertStatic walks or extends static children so that s is fully consumed,
+// splitting existing nodes on their longest common prefix.
+func (n *node) insertStatic(s string) *node {
+	cur := n
+	for s != "" {
+		idx := cur.staticIndex(s[0])
+		if idx < 0 {
+			child := &node{kind: staticNode, prefix: s}
+			cur.static = append(cur.static, child)
+			return child
+		}
+
+		child := cur.static[idx]
+		common := commonPrefixLen(child.prefix, s)
+		if common < len(child.prefix) {
+			split := &node{kind: staticNode, prefix: child.prefix[:common], static: []*node{child}}
+			child.prefix = child.prefix[common:]
+			cur.static[idx] = split
+			child = split
+		}
+
+		s = s[common:]
+		cur = child
+	}
+	return cur
+}
+
+// lookup finds the node matching path, appending extracted parameters.
+//
+// Static children take priority over parameters, which take priority over
+// catch-alls; the search backtracks when a more specific branch dead-ends.
+func (n *node) lookup(path string, params []param) (*node, []param) {
+	if path == "" && len(n.handlers) > 0 {
+		return n, params
+	}
+
+	if path != "" {
+		if idx := n.staticIndex(path[0]); idx >= 0 {
+			child := n.static[idx]
+			if strings.HasPrefix(path, child.prefix) {
+				if found, p := child.lookup(path[len(child.prefix):], params); found != nil {
+					return found, p
+				}
+			}
+		}
+
+		if n.param != nil {
+			end := strings.IndexByte(path, '/')
+			if end < 0 {
+				end = len(path)
+			}
+			if end > 0 {
+				withParam := append(params, param{name: n.param.name, value: path[:end]})
+				if found, p := n.param.lookup(path[end:], withParam); found != nil {
+					return found, p
+				}
+			}
+		}
+	}
+
+	if n.catchAll != nil && len(n.catchAll.handlers) > 0 {
+		return n.catchAll, append(params, param{name: n.catchAll.name, value: path})
+	}
+
+	return nil, params
+}
+
+// staticIndex returns the index of the static child whose prefix starts with c.
+func (n *node) staticIndex(c byte) int {
+	for i, child := range n.static {
+		if child.prefix[0] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// commonPrefixLen returns the length of the longest shared prefix of a and b.
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,132 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sohamratnaparkhi/go-fast/pkg/async"
+)
+
+// waitForGoroutines polls until the goroutine count drops back to baseline.
+func waitForGoroutines(t *testing.T, baseline int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines = %d, want <= %d (leak)", runtime.NumGoroutine(), baseline)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestFuture_Await(t *testing.T) {
+	g := async.New(context.Background())
+
+	user := async.Go(g, func(ctx context.Context) (string, error) { return "john", nil })
+	count := async.Go(g, func(ctx context.Context) (int, error) { return 3, nil })
+
+	name, err := user.Await()
+	if err != nil || name != "john" {
+		t.Fatalf("user.Await() = (%q, %v), want (john, nil)", name, err)
+	}
+	n, err := count.Await()
+	if err != nil || n != 3 {
+		t.Fatalf("count.Await() = (%d, %v), want (3, nil)", n, err)
+	}
+
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+}
+
+func TestGroup_FirstErrorCancelsSiblings(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	boom := errors.New("boom")
+
+	g := async.New(context.Background())
+	slow := async.Go(g, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	g.Go(func(ctx context.Context) error { return boom })
+
+	if err := g.Wait(); !errors.Is(err, boom) {
+		t.Fatalf("Wait() error = %v, want %v", err, boom)
+	}
+	if _, err := slow.Await(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("slow.Await() error = %v, want context.Canceled", err)
+	}
+	if cause := context.Cause(g.Context()); !errors.Is(cause, boom) {
+		t.Fatalf("context cause = %v, want %v", cause, boom)
+	}
+
+	waitForGoroutines(t, baseline)
+}
+
+func TestGroup_ParentCancellation(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	parent, cancel := context.WithCancel(context.Background())
+
+	g := async.New(parent)
+	f := async.Go(g, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	cancel()
+	if _, err := f.Await(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Await() error = %v, want context.Canceled", err)
+	}
+	_ = g.Wait()
+
+	waitForGoroutines(t, baseline)
+}
+
+func TestGroup_PanicBecomesError(t *testing.T) {
+	g := async.New(context.Background())
+	f := async.Go(g, func(ctx context.Context) (int, error) { panic("kaboom") })
+
+	_, err := f.Await()
+	var panicErr *async.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Await() error = %v, want *async.PanicError", err)
+	}
+	if panicErr.Value != "kaboom" || len(panicErr.Stack) == 0 {
+		t.Fatalf("unexpected panic error: %+v", panicErr)
+	}
+	if waitErr := g.Wait(); !errors.As(waitErr, &panicErr) {
+		t.Fatalf("Wait() error = %v, want *async.PanicError", waitErr)
+	}
+}
+
+func TestGroup_SetLimit(t *testing.T) {
+	g := async.New(context.Background())
+	g.SetLimit(2)
+
+	var running, peak atomic.Int32
+	for i := 0; i < 10; i++ {
+		g.Go(func(ctx context.Context) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("peak concurrency = %d, want <= 2", p)
+	}
+}
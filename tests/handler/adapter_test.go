@@ -2,9 +2,11 @@ package handler_test
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
@@ -124,6 +126,78 @@ func TestAdapt_PathFieldWithoutParams_Returns400(t *testing.T) {
 	}
 }
 
+func TestAdapt_PathFieldFromServeMuxPattern(t *testing.T) {
+	type input struct {
+		ID int `json:"path:id"`
+	}
+
+	h, err := handler.Adapt(func(req input) (map[string]int, error) {
+		return map[string]int{"id": req.ID}, nil
+	}, handler.WithPattern("GET /users/{id}"))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{id}", h)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var got map[string]int
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+	if got["id"] != 42 {
+		t.Fatalf("id = %d, want 42", got["id"])
+	}
+}
+
+func TestAdapt_EmptyServeMuxRemainder(t *testing.T) {
+	var got *string
+	h, err := handler.Adapt(func(req struct {
+		Path string `json:"path:path"`
+	}) error {
+		got = &req.Path
+		return nil
+	}, handler.WithPattern("GET /files/{path...}"))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /files/{path...}", h)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	if got == nil || *got != "" {
+		t.Fatalf("path = %v, want empty remainder", got)
+	}
+}
+
+func TestAdapt_PathTagNotInPattern_Error(t *testing.T) {
+	type input struct {
+		ID int `json:"path:usr_id"`
+	}
+
+	for _, pattern := range []string{"GET /users/{id}", "/users/:id", "/files/{path...}"} {
+		if _, err := handler.Adapt(func(req input) error { return nil }, handler.WithPattern(pattern)); err == nil {
+			t.Errorf("pattern %q: expected error for unknown path tag, got nil", pattern)
+		}
+	}
+
+	if _, err := handler.Adapt(func(req input) error { return nil }, handler.WithPattern("GET /users/{usr_id}/{$}")); err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+}
+
 // --- Form Adapter Tests ---
 
 func TestAdapt_FormFields(t *testing.T) {
@@ -382,3 +456,136 @@ func TestAdapt_FileUpload_MissingFile_Returns400(t *testing.T) {
 // Ensure unused imports are referenced.
 var _ = fmt.Sprintf
 var _ = errors.New
+
+// --- HTTP Error Tests ---
+
+func TestAdapt_HTTPErrorChoosesStatus(t *testing.T) {
+	cases := []struct {
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{handler.NotFound("user not found"), http.StatusNotFound, "user not found"},
+		{handler.Conflict("email taken"), http.StatusConflict, "email taken"},
+		{fmt.Errorf("lookup: %w", handler.Forbidden("")), http.StatusForbidden, "Forbidden"},
+		{errors.New("pq: relation \"users\" does not exist"), http.StatusInternalServerError, "Internal Server Error"},
+	}
+
+	for _, tc := range cases {
+		h, err := handler.Adapt(func(req struct{}) (*createUserOutput, error) { return nil, tc.err })
+		if err != nil {
+			t.Fatalf("Adapt() error = %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if w.Code != tc.wantStatus {
+			t.Errorf("%v: status = %d, want %d", tc.err, w.Code, tc.wantStatus)
+		}
+
+		var got map[string]any
+		if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+			t.Fatalf("decode response: %v", decodeErr)
+		}
+		if got["error"] != tc.wantMsg {
+			t.Errorf("%v: error = %q, want %q", tc.err, got["error"], tc.wantMsg)
+		}
+	}
+}
+
+func TestAdapt_HTTPErrorDetailsAndHeaders(t *testing.T) {
+	h, err := handler.Adapt(func(req struct{}) error {
+		return handler.TooManyRequests("slow down").
+			WithHeader("Retry-After", "30").
+			WithDetails(map[string]int{"limit": 10}).
+			WithCause(errors.New("bucket empty"))
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Fatalf("Retry-After = %q, want %q", got, "30")
+	}
+	if strings.Contains(w.Body.String(), "bucket empty") {
+		t.Fatalf("internal cause leaked: %s", w.Body.String())
+	}
+
+	var got struct {
+		Error   string         `json:"error"`
+		Details map[string]int `json:"details"`
+	}
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+	if got.Error != "slow down" || got.Details["limit"] != 10 {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestAdapt_WithDecoder(t *testing.T) {
+	type input struct {
+		Body createUserBody `json:"body"`
+	}
+
+	h, err := handler.Adapt(func(req input) (*createUserBody, error) {
+		return &req.Body, nil
+	}, handler.WithDecoder("text/csv", handler.DecoderFunc(func(r io.Reader, v any) error {
+		record, err := csv.NewReader(r).Read()
+		if err != nil {
+			return err
+		}
+		*v.(*createUserBody) = createUserBody{Name: record[0], Email: record[1]}
+		return nil
+	})))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("ann,ann@test.com\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"email":"ann@test.com"`) {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"ann"}`))
+	w = httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want JSON default without Content-Type", w.Code)
+	}
+}
+
+func TestAdapt_UnsupportedMediaType_Returns415(t *testing.T) {
+	type input struct {
+		Body createUserBody `json:"body"`
+		Page int            `json:"query:page"`
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil }, handler.WithAllBindingErrors())
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users?page=abc", strings.NewReader(`<user/>`))
+	req.Header.Set("Content-Type", "application/xml")
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnsupportedMediaType)
+	}
+	if !strings.Contains(w.Body.String(), "unsupported media type") {
+		t.Fatalf("body = %s, want unsupported media type message", w.Body.String())
+	}
+}
@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+type searchInput struct {
+	Page    int     `json:"query:page"`
+	Size    int     `json:"query:size"`
+	Retries int     `json:"header:X-Retries"`
+	Score   float64 `json:"cookie:score"`
+}
+
+type bindingResponse struct {
+	Error  string `json:"error"`
+	Fields []struct {
+		Field   string `json:"field"`
+		Source  string `json:"source"`
+		Value   string `json:"value"`
+		Message string `json:"message"`
+	} `json:"fields"`
+}
+
+func newSearchRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/search?page=abc&size=10", nil)
+	req.Header.Set("X-Retries", "many")
+	return req
+}
+
+func TestAdapt_AllBindingErrors(t *testing.T) {
+	h, err := handler.Adapt(func(req searchInput) error { return nil }, handler.WithAllBindingErrors())
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, newSearchRequest())
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	var got bindingResponse
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+
+	if got.Error != "binding failed" || len(got.Fields) != 3 {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+
+	wantOrder := []struct{ field, source, value string }{
+		{"page", "query", "abc"},
+		{"X-Retries", "header", "many"},
+		{"score", "cookie", ""},
+	}
+	for i, want := range wantOrder {
+		f := got.Fields[i]
+		if f.Field != want.field || f.Source != want.source || f.Value != want.value || f.Message == "" {
+			t.Errorf("fields[%d] = %+v, want %+v", i, f, want)
+		}
+	}
+}
+
+func TestAdapt_AllBindingErrors_MiddlewareSeesTypedErrors(t *testing.T) {
+	var seen handler.BindingErrors
+	capture := func(call *handler.Call, next func() error) error {
+		err := next()
+		errors.As(err, &seen)
+		return err
+	}
+
+	h, err := handler.Adapt(func(req searchInput) error { return nil },
+		handler.WithAllBindingErrors(), handler.WithMiddleware(capture))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	h(httptest.NewRecorder(), newSearchRequest())
+
+	if len(seen) != 3 || seen[0].Field != "page" || seen[0].Err == nil {
+		t.Fatalf("middleware saw %+v", seen)
+	}
+	var resolveErr *handler.ResolveError
+	if !errors.As(seen[0].Err, &resolveErr) || resolveErr.Raw != "abc" {
+		t.Fatalf("cause = %v, want *handler.ResolveError with raw value", seen[0].Err)
+	}
+}
+
+func TestAdapt_DefaultStopsAtFirstBindingError(t *testing.T) {
+	h, err := handler.Adapt(func(req searchInput) error { return nil })
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, newSearchRequest())
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	var got map[string]string
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+	if got["error"] == "" {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+}
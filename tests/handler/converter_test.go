@@ -0,0 +1,125 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+// color is an enum bound through a registered converter.
+type color int
+
+const (
+	red color = iota + 1
+	green
+)
+
+// level is an enum that parses itself.
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "debug":
+		*l = 1
+	case "info":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+func init() {
+	handler.RegisterConverter(reflect.TypeOf(color(0)), func(raw string) (any, error) {
+		switch raw {
+		case "red":
+			return red, nil
+		case "green":
+			return green, nil
+		}
+		return nil, errors.New("unknown color")
+	})
+}
+
+func TestQueryResolver_TextUnmarshaler(t *testing.T) {
+	resolver := handler.NewQueryResolver(0, "ip", reflect.TypeOf(netip.Addr{}))
+	req := httptest.NewRequest(http.MethodGet, "/?ip=10.0.0.1", nil)
+
+	value, err := resolver.Resolve(&handler.Context{Request: req})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got := value.Interface().(netip.Addr); got != netip.MustParseAddr("10.0.0.1") {
+		t.Fatalf("resolved value = %v, want 10.0.0.1", got)
+	}
+}
+
+func TestPathVarResolver_TextUnmarshalerPointerAndSlice(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx := &handler.Context{Request: req, Params: map[string]string{"level": "info"}}
+
+	value, err := handler.NewPathVarResolver(0, "level", reflect.TypeOf((*level)(nil))).Resolve(ctx)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got := value.Interface().(*level); got == nil || *got != 2 {
+		t.Fatalf("resolved value = %v, want pointer to 2", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/?level=debug&level=bogus", nil)
+	_, err = handler.NewQueryResolver(0, "level", reflect.TypeOf([]level{})).Resolve(&handler.Context{Request: req})
+	var re *handler.ResolveError
+	if !errors.As(err, &re) || re.Raw != "bogus" {
+		t.Fatalf("Resolve() error = %v, want ResolveError for bogus", err)
+	}
+}
+
+func TestRegisterConverter_TakesPrecedence(t *testing.T) {
+	type input struct {
+		Color  color   `json:"query:color"`
+		Colors []color `json:"query:colors,csv"`
+	}
+
+	var got input
+	h, err := handler.Adapt(func(req input) error {
+		got = req
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?color=green&colors=red,green", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	if got.Color != green || !reflect.DeepEqual(got.Colors, []color{red, green}) {
+		t.Fatalf("unexpected mapping: %+v", got)
+	}
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?color=blue", nil))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `invalid query parameter \"color\"`) {
+		t.Fatalf("status = %d, body = %s; want 400 for unknown color", w.Code, w.Body.String())
+	}
+}
+
+func TestRegisterConverter_WrongResultType(t *testing.T) {
+	type mistyped struct{ v string }
+	handler.RegisterConverter(reflect.TypeOf(mistyped{}), func(raw string) (any, error) { return raw, nil })
+
+	resolver := handler.NewHeaderResolver(0, "X-Value", reflect.TypeOf(mistyped{}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Value", "x")
+	if _, err := resolver.Resolve(&handler.Context{Request: req}); err == nil {
+		t.Fatal("expected error for converter returning the wrong type, got nil")
+	}
+}
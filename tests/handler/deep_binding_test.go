@@ -0,0 +1,296 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+type searchSort struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
+}
+
+type searchFilter struct {
+	Status  string     `json:"status"`
+	Owner   string     `json:"owner"`
+	MinSize *int       `json:"min_size"`
+	Since   time.Time  `json:"since"`
+	Tags    []string   `json:"tags"`
+	Range   *sizeRange `json:"range"`
+}
+
+type sizeRange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+func TestAdapt_DeepQueryWithPrefix(t *testing.T) {
+	type input struct {
+		Filter searchFilter      `json:"query:filter,deep"`
+		Sort   []searchSort      `json:"query:sort,deep"`
+		Labels map[string]string `json:"query:labels,deep"`
+		Page   int               `json:"query:page"`
+	}
+
+	var got input
+	h, err := handler.Adapt(func(req input) error {
+		got = req
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	query := "filter[status]=open&filter.owner=me&filter[min_size]=10&filter[since]=2024-01-02T00:00:00Z" +
+		"&filter[tags][]=a&filter[tags][]=b&filter[range][from]=1&filter[range][to]=5" +
+		"&sort[10][field]=name&sort[2][field]=created&sort[2][desc]=true" +
+		"&labels[env]=prod&labels[team]=core&page=3"
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?"+query, nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+
+	minSize := 10
+	want := input{
+		Filter: searchFilter{
+			Status:  "open",
+			Owner:   "me",
+			MinSize: &minSize,
+			Since:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Tags:    []string{"a", "b"},
+			Range:   &sizeRange{From: 1, To: 5},
+		},
+		Sort:   []searchSort{{Field: "created", Desc: true}, {Field: "name"}},
+		Labels: map[string]string{"env": "prod", "team": "core"},
+		Page:   3,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !reflect.DeepEqual(got, input{}) {
+		t.Fatalf("absent deep fields should bind zero values, got %+v", got)
+	}
+}
+
+func TestAdapt_DeepRepeatedAppendKey(t *testing.T) {
+	var got []searchSort
+	h, err := handler.Adapt(func(req struct {
+		Sort []searchSort `json:"query:sort,deep"`
+	}) error {
+		got = req.Sort
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?sort[][field]=name&sort[][field]=created", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	want := []searchSort{{Field: "name"}, {Field: "created"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestAdapt_DeepOptional(t *testing.T) {
+	type filter struct {
+		Min  handler.Optional[int]    `json:"min"`
+		Max  handler.Optional[int]    `json:"max"`
+		Name handler.Optional[string] `json:"name"`
+		IDs  []handler.Optional[int]  `json:"ids"`
+	}
+
+	var got filter
+	h, err := handler.Adapt(func(req struct {
+		Filter filter `json:"query:f,deep"`
+	}) error {
+		got = req.Filter
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?f[min]=3&f[name]=&f[ids]=1&f[ids]=2", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	want := filter{
+		Min:  handler.Optional[int]{Value: 3, Present: true},
+		Name: handler.Optional[string]{Present: true},
+		IDs:  []handler.Optional[int]{{Value: 1, Present: true}, {Value: 2, Present: true}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?f[min][Value]=3", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d: Optional fields must not be keyed by their Go fields", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestAdapt_DeepWholeQueryAndForm(t *testing.T) {
+	type params struct {
+		Q      string       `json:"q"`
+		Filter searchFilter `json:"filter"`
+	}
+
+	var gotQuery, gotForm params
+	h, err := handler.Adapt(func(req struct {
+		Query params `json:"query"`
+		Form  params `json:"form"`
+	}) error {
+		gotQuery, gotForm = req.Query, req.Form
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	form := url.Values{"q": {"posted"}, "filter[owner]": {"you"}}
+	req := httptest.NewRequest(http.MethodPost, "/?q=go&filter[status]=open", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	if gotQuery.Q != "go" || gotQuery.Filter.Status != "open" {
+		t.Fatalf("query = %+v", gotQuery)
+	}
+	if gotForm.Q != "posted" || gotForm.Filter.Owner != "you" {
+		t.Fatalf("form = %+v", gotForm)
+	}
+}
+
+func TestAdapt_DeepBindingErrorNamesKey(t *testing.T) {
+	h, err := handler.Adapt(func(req struct {
+		Filter searchFilter `json:"query:filter,deep"`
+	}) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?filter[range][from]=x", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	var resp struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Error != `invalid query parameter "filter[range][from]"` {
+		t.Fatalf("error = %q, want it to name filter[range][from]", resp.Error)
+	}
+}
+
+func TestAdapt_DeepValidation(t *testing.T) {
+	type filter struct {
+		Status string `json:"status" validate:"oneof=open closed"`
+	}
+
+	h, err := handler.Adapt(func(req struct {
+		Filter filter `json:"query:filter,deep"`
+	}) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?filter[status]=pending", nil))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if !strings.Contains(w.Body.String(), `"field":"filter.status"`) {
+		t.Fatalf("body = %s, want field filter.status", w.Body.String())
+	}
+}
+
+func TestAdapt_InvalidDeepTags(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   any
+		want string
+	}{
+		{
+			name: "scalar",
+			fn: func(req struct {
+				Page int `json:"query:page,deep"`
+			}) error {
+				return nil
+			},
+			want: "requires a struct, map or slice",
+		},
+		{
+			name: "whole query slice",
+			fn: func(req struct {
+				All []string `json:"query"`
+			}) error {
+				return nil
+			},
+			want: "requires a struct or map",
+		},
+		{
+			name: "header",
+			fn: func(req struct {
+				Meta map[string]string `json:"header:meta,deep"`
+			}) error {
+				return nil
+			},
+			want: "only supported for query and form",
+		},
+		{
+			name: "unsupported nested type",
+			fn: func(req struct {
+				Filter struct {
+					Fn func() `json:"fn"`
+				} `json:"query:filter,deep"`
+			}) error {
+				return nil
+			},
+			want: "unsupported field type",
+		},
+		{
+			name: "with default",
+			fn: func(req struct {
+				Labels map[string]string `json:"query:labels,deep,default=x"`
+			}) error {
+				return nil
+			},
+			want: "default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.Adapt(tt.fn)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Adapt() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
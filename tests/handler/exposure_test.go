@@ -0,0 +1,110 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+func TestAdapt_ProductionHidesInternalErrors(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	h, err := handler.Adapt(func(req struct{}) error {
+		return errors.New("pq: relation \"users\" does not exist")
+	}, handler.WithLogger(logger))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if strings.Contains(w.Body.String(), "pq:") {
+		t.Fatalf("body leaks internal error: %s", w.Body.String())
+	}
+
+	var got map[string]string
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+	id := got["correlation_id"]
+	if id == "" || w.Header().Get("X-Request-ID") != id {
+		t.Fatalf("correlation_id = %q, header = %q", id, w.Header().Get("X-Request-ID"))
+	}
+	if !strings.Contains(logs.String(), id) || !strings.Contains(logs.String(), "pq: relation") {
+		t.Fatalf("log = %q, want real error with correlation ID", logs.String())
+	}
+}
+
+func TestAdapt_ProductionUsesGenericBindingMessages(t *testing.T) {
+	type input struct {
+		Body struct {
+			Name string `json:"name"`
+		} `json:"body"`
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil })
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": 42}`)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "invalid request body") || strings.Contains(body, "Go value") {
+		t.Fatalf("body = %s, want generic message", body)
+	}
+}
+
+func TestAdapt_ProductionReusesIncomingRequestID(t *testing.T) {
+	type input struct {
+		Page int `json:"query:page"`
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil })
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/?page=abc", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	h(w, req)
+
+	if got := w.Header().Get("X-Request-ID"); got != "req-123" {
+		t.Fatalf("X-Request-ID = %q, want %q", got, "req-123")
+	}
+	if body := w.Body.String(); !strings.Contains(body, `invalid query parameter \"page\"`) || strings.Contains(body, "strconv") {
+		t.Fatalf("body = %s, want generic query message", body)
+	}
+}
+
+func TestAdapt_DevelopmentExposesErrors(t *testing.T) {
+	h, err := handler.Adapt(func(req struct{}) error {
+		return errors.New("pq: relation \"users\" does not exist")
+	}, handler.WithErrorExposure(handler.ExposeDevelopment))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !strings.Contains(w.Body.String(), "pq: relation") {
+		t.Fatalf("body = %s, want verbose error", w.Body.String())
+	}
+}
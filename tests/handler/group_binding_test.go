@@ -0,0 +1,179 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+type pagination struct {
+	Page int `json:"query:page,default=1" validate:"min=1"`
+	Size int `json:"query:size,default=20" validate:"max=100"`
+}
+
+type listFilter struct {
+	Status []string `json:"query:status"`
+	Owner  string   `json:"header:X-Owner"`
+}
+
+func TestAdapt_EmbeddedAndInlineGroups(t *testing.T) {
+	type input struct {
+		pagination
+		Filter *listFilter `json:",inline"`
+		ID     string      `json:"path:id"`
+	}
+
+	var got input
+	h, err := handler.Adapt(func(req input) error {
+		got = req
+		return nil
+	}, handler.WithPattern("/teams/{id}/members"))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/teams/t1/members?page=2&status=active&status=invited", nil)
+	req.SetPathValue("id", "t1")
+	req.Header.Set("X-Owner", "ada")
+	w := httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+
+	if got.Page != 2 || got.Size != 20 || got.ID != "t1" {
+		t.Fatalf("got %+v, want page 2, size 20 and id t1", got)
+	}
+	if got.Filter == nil {
+		t.Fatal("inline pointer group was not allocated")
+	}
+	if strings.Join(got.Filter.Status, ",") != "active,invited" || got.Filter.Owner != "ada" {
+		t.Fatalf("Filter = %+v", *got.Filter)
+	}
+}
+
+func TestAdapt_GroupBindingErrorsAndValidation(t *testing.T) {
+	type input struct {
+		pagination
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil })
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?page=x", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(w.Body.String(), `query parameter \"page\"`) {
+		t.Fatalf("body = %s, want error naming query parameter page", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?page=0&size=500", nil))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	var resp struct {
+		Fields []handler.FieldError `json:"fields"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Fields) != 2 || resp.Fields[0].Field != "page" || resp.Fields[1].Field != "size" {
+		t.Fatalf("fields = %+v, want page and size", resp.Fields)
+	}
+}
+
+func TestAdapt_UntaggedNestedStructIsNotAGroup(t *testing.T) {
+	type input struct {
+		Paging pagination
+	}
+
+	var got input
+	h, err := handler.Adapt(func(req input) error {
+		got = req
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?page=5", nil))
+	if got.Paging.Page != 0 {
+		t.Fatalf("Paging.Page = %d, want 0 without the inline option", got.Paging.Page)
+	}
+}
+
+type RecursiveGroup struct {
+	Name string `json:"query:name"`
+	*RecursiveGroup
+}
+
+func TestAdapt_InvalidGroups(t *testing.T) {
+	type payload struct {
+		Name string `json:"name"`
+	}
+	type bodyGroup struct {
+		Body payload `json:"body"`
+	}
+
+	tests := []struct {
+		name string
+		fn   any
+		want string
+	}{
+		{
+			name: "inline non-struct",
+			fn: func(req struct {
+				Page int `json:",inline"`
+			}) error {
+				return nil
+			},
+			want: "must be a struct",
+		},
+		{
+			name: "unexported embedded pointer",
+			fn: func(req struct {
+				*listFilter
+			}) error {
+				return nil
+			},
+			want: "must be exported",
+		},
+		{
+			name: "recursive",
+			fn: func(req struct {
+				Group RecursiveGroup `json:",inline"`
+			}) error {
+				return nil
+			},
+			want: "contains itself",
+		},
+		{
+			name: "body in group and input",
+			fn: func(req struct {
+				bodyGroup
+				Body payload `json:"body"`
+			}) error {
+				return nil
+			},
+			want: "multiple body fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.Adapt(tt.fn)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Adapt() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
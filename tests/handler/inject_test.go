@@ -0,0 +1,167 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+type ctxKey struct{}
+
+func TestAdapt_LeadingContext(t *testing.T) {
+	var got any
+	h, err := handler.Adapt(func(ctx context.Context, req struct {
+		ID string `json:"query:id"`
+	}) error {
+		got = ctx.Value(ctxKey{})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?id=1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), ctxKey{}, "tenant"))
+	w := httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	if got != "tenant" {
+		t.Fatalf("context value = %v, want tenant", got)
+	}
+}
+
+func TestAdapt_InjectsRequestPrimitives(t *testing.T) {
+	type input struct {
+		Ctx     context.Context
+		Request *http.Request
+		Body    io.Reader
+		Query   url.Values
+		Header  http.Header
+		Page    int `json:"query:page"`
+	}
+
+	var got input
+	var body string
+	h, err := handler.Adapt(func(req input) (string, error) {
+		got = req
+		data, _ := io.ReadAll(req.Body)
+		body = string(data)
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/?page=2&sort=name", strings.NewReader("raw bytes"))
+	req.Header.Set("X-Trace", "abc")
+	w := httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got.Ctx == nil || got.Request == nil || got.Request.URL.Path != "/" {
+		t.Fatalf("context or request not injected: %+v", got)
+	}
+	if body != "raw bytes" {
+		t.Fatalf("body = %q, want raw bytes", body)
+	}
+	if got.Query.Get("sort") != "name" || got.Header.Get("X-Trace") != "abc" || got.Page != 2 {
+		t.Fatalf("query = %v, header = %v, page = %d", got.Query, got.Header, got.Page)
+	}
+}
+
+func TestAdapt_InjectedResponseWriter(t *testing.T) {
+	h, err := handler.Adapt(func(req struct {
+		W      http.ResponseWriter
+		Stream bool `json:"query:stream"`
+	}) (string, error) {
+		if !req.Stream {
+			return "encoded", nil
+		}
+		req.W.Header().Set("Content-Type", "text/plain")
+		req.W.WriteHeader(http.StatusAccepted)
+		_, _ = io.WriteString(req.W, "chunk")
+		_ = http.NewResponseController(req.W).Flush()
+		return "", errors.New("lost connection")
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?stream=true", nil))
+	if w.Code != http.StatusAccepted || w.Body.String() != "chunk" || !w.Flushed {
+		t.Fatalf("status = %d, body = %q, flushed = %v; want the handler's own response", w.Code, w.Body.String(), w.Flushed)
+	}
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "encoded") {
+		t.Fatalf("status = %d, body = %q; want the encoded result", w.Code, w.Body.String())
+	}
+}
+
+func TestAdapt_InvalidInjection(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   any
+		want string
+	}{
+		{
+			name: "context not first",
+			fn: func(req struct{}, ctx context.Context) error {
+				return nil
+			},
+			want: "optionally preceded by context.Context",
+		},
+		{
+			name: "unexported primitive",
+			fn: func(req struct {
+				r *http.Request
+			}) error {
+				return nil
+			},
+			want: "injected but not exported",
+		},
+		{
+			name: "two readers",
+			fn: func(req struct {
+				A io.Reader
+				B io.Reader
+			}) error {
+				return nil
+			},
+			want: "multiple io.Reader fields",
+		},
+		{
+			name: "reader with body",
+			fn: func(req struct {
+				Raw  io.Reader
+				Data struct {
+					Name string `json:"name"`
+				} `json:"body"`
+			}) error {
+				return nil
+			},
+			want: "cannot combine io.Reader field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.Adapt(tt.fn)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Adapt() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
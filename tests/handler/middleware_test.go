@@ -0,0 +1,157 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+type greetInput struct {
+	Name string `json:"query:name"`
+}
+
+type greetOutput struct {
+	Message string `json:"message"`
+}
+
+func greet(req greetInput) (*greetOutput, error) {
+	if req.Name == "" {
+		return nil, errors.New("name is empty")
+	}
+	return &greetOutput{Message: "hello " + req.Name}, nil
+}
+
+func TestAdapt_MiddlewareOrderAndAccess(t *testing.T) {
+	var trace []string
+	record := func(name string) handler.Middleware {
+		return func(call *handler.Call, next func() error) error {
+			trace = append(trace, name+":before")
+			err := next()
+			trace = append(trace, name+":after")
+			return err
+		}
+	}
+
+	var seenInput greetInput
+	var seenOutput *greetOutput
+	inspect := func(call *handler.Call, next func() error) error {
+		err := next()
+		seenInput = call.Input.Interface().(greetInput)
+		seenOutput = call.Results[0].Interface().(*greetOutput)
+		return err
+	}
+
+	h, err := handler.Adapt(greet,
+		handler.WithMiddleware(record("outer"), record("inner")),
+		handler.WithMiddleware(inspect))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/greet?name=ada", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	want := []string{"outer:before", "inner:before", "inner:after", "outer:after"}
+	if !reflect.DeepEqual(trace, want) {
+		t.Fatalf("trace = %v, want %v", trace, want)
+	}
+	if seenInput.Name != "ada" || seenOutput == nil || seenOutput.Message != "hello ada" {
+		t.Fatalf("middleware saw input %+v output %+v", seenInput, seenOutput)
+	}
+}
+
+func TestAdapt_MiddlewareRewritesResults(t *testing.T) {
+	shout := func(call *handler.Call, next func() error) error {
+		if err := next(); err != nil {
+			return err
+		}
+		out := call.Results[0].Interface().(*greetOutput)
+		call.Results[0] = reflect.ValueOf(&greetOutput{Message: strings.ToUpper(out.Message)})
+		return nil
+	}
+
+	h, err := handler.Adapt(greet, handler.WithMiddleware(shout))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/greet?name=ada", nil))
+
+	var got greetOutput
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+	if got.Message != "HELLO ADA" {
+		t.Fatalf("message = %q, want %q", got.Message, "HELLO ADA")
+	}
+}
+
+func TestAdapt_MiddlewareSeesErrors(t *testing.T) {
+	var handlerErr, bindErr error
+	capture := func(target *error) handler.Middleware {
+		return func(call *handler.Call, next func() error) error {
+			*target = next()
+			return *target
+		}
+	}
+
+	h, err := handler.Adapt(greet, handler.WithMiddleware(capture(&handlerErr)))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/greet", nil))
+	if handlerErr == nil || w.Code != http.StatusInternalServerError {
+		t.Fatalf("handler error = %v, status = %d", handlerErr, w.Code)
+	}
+
+	type pageInput struct {
+		Page int `json:"query:page"`
+	}
+	h, err = handler.Adapt(func(req pageInput) error { return nil }, handler.WithMiddleware(capture(&bindErr)))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?page=abc", nil))
+	if bindErr == nil || w.Code != http.StatusBadRequest {
+		t.Fatalf("binding error = %v, status = %d", bindErr, w.Code)
+	}
+}
+
+func TestAdapt_MiddlewareShortCircuits(t *testing.T) {
+	called := false
+	deny := func(call *handler.Call, next func() error) error {
+		call.Writer.WriteHeader(http.StatusTeapot)
+		return nil
+	}
+
+	h, err := handler.Adapt(func(req greetInput) (*greetOutput, error) {
+		called = true
+		return &greetOutput{}, nil
+	}, handler.WithMiddleware(deny))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/greet?name=ada", nil))
+
+	if called {
+		t.Fatal("handler ran despite short-circuit")
+	}
+	if w.Code != http.StatusTeapot || w.Body.Len() != 0 {
+		t.Fatalf("status = %d body = %q, want 418 with empty body", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,106 @@
+package handler_test
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+// tempFiles lists the multipart temporary files in dir.
+func tempFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "multipart-") {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+func TestAdapt_RemovesMultipartTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+
+	type input struct {
+		Doc  *multipart.FileHeader `json:"file:doc"`
+		Page int                   `json:"query:page"`
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		fn     func(req input) error
+		status int
+	}{
+		{
+			name: "handler returns",
+			fn: func(req input) error {
+				if len(tempFiles(t, dir)) == 0 {
+					t.Error("upload was not spooled to a temporary file")
+				}
+				return nil
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "handler fails",
+			fn:     func(req input) error { return errors.New("boom") },
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "binding fails after the form is parsed",
+			query:  "?page=x",
+			fn:     func(req input) error { return nil },
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "handler panics",
+			fn:     func(req input) error { panic("boom") },
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := handler.Adapt(tt.fn, handler.WithMultipartMemory(1))
+			if err != nil {
+				t.Fatalf("Adapt() error = %v", err)
+			}
+
+			req := newMultipartRequest(t, nil, [2]string{"doc", strings.Repeat("x", 4096)})
+			req.URL.RawQuery = strings.TrimPrefix(tt.query, "?")
+			// Resolve from a copy, as routers do, so the server's own cleanup
+			// of the original request would not find the form.
+			req = handler.WithParams(req, map[string]string{})
+
+			w := httptest.NewRecorder()
+			func() {
+				defer func() {
+					if recover() != nil {
+						w.WriteHeader(http.StatusInternalServerError)
+					}
+				}()
+				h(w, req)
+			}()
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if left := tempFiles(t, dir); len(left) > 0 {
+				t.Fatalf("temporary files left behind: %v", left)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package handler_test
+
+import (
+	"bytes"
+	"io"
+	"iter"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+// newMultipartRequest builds a POST request with one part per entry of
+// files, keyed by field name, plus the given form values.
+func newMultipartRequest(t *testing.T, values map[string]string, files ...[2]string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for name, value := range values {
+		if err := mw.WriteField(name, value); err != nil {
+			t.Fatalf("WriteField() error = %v", err)
+		}
+	}
+	for i, file := range files {
+		part, err := mw.CreateFormFile(file[0], file[0]+string(rune('a'+i))+".txt")
+		if err != nil {
+			t.Fatalf("CreateFormFile() error = %v", err)
+		}
+		_, _ = part.Write([]byte(file[1]))
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestAdapt_MultipleFiles(t *testing.T) {
+	var got []*multipart.FileHeader
+	h, err := handler.Adapt(func(req struct {
+		Photos []*multipart.FileHeader `json:"file:photos"`
+	}) error {
+		got = req.Photos
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, newMultipartRequest(t, nil, [2]string{"photos", "one"}, [2]string{"photos", "three"}))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	if len(got) != 2 || got[0].Size != 3 || got[1].Size != 5 {
+		t.Fatalf("got %d files, want sizes 3 and 5", len(got))
+	}
+
+	w = httptest.NewRecorder()
+	h(w, newMultipartRequest(t, map[string]string{"note": "no files"}))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `missing file \"photos\"`) {
+		t.Fatalf("status = %d, body = %s, want missing file", w.Code, w.Body.String())
+	}
+}
+
+func TestAdapt_MultipartMemoryOverflow(t *testing.T) {
+	h, err := handler.Adapt(func(req struct {
+		Note string `json:"form:note"`
+	}) error {
+		return nil
+	}, handler.WithMultipartMemory(1024))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	// Non-file values may exceed the memory limit by at most 10 MB.
+	w := httptest.NewRecorder()
+	h(w, newMultipartRequest(t, map[string]string{"note": strings.Repeat("x", 11<<20)}))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	if _, err := handler.Adapt(func(req struct{}) {}, handler.WithMultipartMemory(0)); err == nil {
+		t.Fatal("expected error for zero multipart memory, got nil")
+	}
+}
+
+func TestAdapt_MultipartReader(t *testing.T) {
+	var names, contents []string
+	h, err := handler.Adapt(func(req struct {
+		Upload *multipart.Reader `json:"multipart"`
+		Tenant string            `json:"header:X-Tenant"`
+	}) error {
+		for {
+			part, err := req.Upload.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			data, _ := io.ReadAll(part)
+			names = append(names, part.FormName())
+			contents = append(contents, string(data))
+		}
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, newMultipartRequest(t, nil, [2]string{"a", "first"}, [2]string{"b", "second"}))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	if strings.Join(names, ",") != "a,b" || strings.Join(contents, ",") != "first,second" {
+		t.Fatalf("names = %v, contents = %v", names, contents)
+	}
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	h(w, req)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestAdapt_MultipartParts(t *testing.T) {
+	var total int64
+	h, err := handler.Adapt(func(req struct {
+		Parts iter.Seq2[*multipart.Part, error] `json:"multipart"`
+	}) error {
+		for part, err := range req.Parts {
+			if err != nil {
+				return err
+			}
+			n, _ := io.Copy(io.Discard, part)
+			total += n
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, newMultipartRequest(t, nil, [2]string{"a", "12345"}, [2]string{"b", "678"}))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	if total != 8 {
+		t.Fatalf("streamed %d bytes, want 8", total)
+	}
+}
+
+func TestAdapt_InvalidMultipartFields(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   any
+		want string
+	}{
+		{
+			name: "wrong file type",
+			fn: func(req struct {
+				Photos []multipart.FileHeader `json:"file:photos"`
+			}) error {
+				return nil
+			},
+			want: "must be *multipart.FileHeader or []*multipart.FileHeader",
+		},
+		{
+			name: "wrong stream type",
+			fn: func(req struct {
+				Upload io.Reader `json:"multipart"`
+			}) error {
+				return nil
+			},
+			want: "multipart field must be",
+		},
+		{
+			name: "stream with form",
+			fn: func(req struct {
+				Upload *multipart.Reader `json:"multipart"`
+				Title  string            `json:"form:title"`
+			}) error {
+				return nil
+			},
+			want: "cannot combine multipart field",
+		},
+		{
+			name: "two streams",
+			fn: func(req struct {
+				A *multipart.Reader `json:"multipart"`
+				G struct {
+					B *multipart.Reader `json:"multipart"`
+				} `json:",inline"`
+			}) error {
+				return nil
+			},
+			want: "multiple multipart fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.Adapt(tt.fn)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Adapt() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+type greeting struct {
+	Message string `json:"message" xml:"message"`
+}
+
+// textRenderer writes the message as plain text.
+var textRenderer = handler.RendererFunc(func(w io.Writer, v any) error {
+	_, err := io.WriteString(w, v.(*greeting).Message)
+	return err
+})
+
+func negotiatingHandler(t *testing.T, opts ...handler.Option) http.HandlerFunc {
+	t.Helper()
+	h, err := handler.Adapt(func(req struct{}) (*greeting, error) {
+		return &greeting{Message: "hello"}, nil
+	}, opts...)
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+	return h
+}
+
+func TestAdapt_NegotiatesRenderer(t *testing.T) {
+	h := negotiatingHandler(t,
+		handler.WithRenderer("application/xml", handler.XMLRenderer{}),
+		handler.WithRenderer("text/plain", textRenderer),
+	)
+
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"", "application/json", `{"message":"hello"}`},
+		{"*/*", "application/json", `{"message":"hello"}`},
+		{"application/xml", "application/xml", `<greeting><message>hello</message></greeting>`},
+		{"application/json;q=0.5, text/*;q=0.8", "text/plain", "hello"},
+		{"text/plain;q=0, */*;q=0.1", "application/json", `{"message":"hello"}`},
+		{"application/xml;q=0.9, application/json;q=0.9", "application/json", `{"message":"hello"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			h(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.contentType {
+				t.Fatalf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.body {
+				t.Fatalf("body = %q, want %q", got, tt.body)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept" {
+				t.Fatalf("Vary = %q, want Accept", got)
+			}
+		})
+	}
+}
+
+func TestAdapt_NotAcceptable_Returns406(t *testing.T) {
+	called := false
+	h, err := handler.Adapt(func(req struct{}) (*greeting, error) {
+		called = true
+		return &greeting{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/cbor")
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusNotAcceptable {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotAcceptable)
+	}
+	if called {
+		t.Fatal("handler should not run when no renderer is acceptable")
+	}
+
+	var got struct {
+		Details struct {
+			Available []string `json:"available"`
+		} `json:"details"`
+	}
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+	if len(got.Details.Available) != 1 || got.Details.Available[0] != "application/json" {
+		t.Fatalf("available = %v, want [application/json]", got.Details.Available)
+	}
+}
+
+func TestAdapt_NoResultsIgnoreAccept(t *testing.T) {
+	h, err := handler.Adapt(func(req struct{}) error { return nil })
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	req.Header.Set("Accept", "application/cbor")
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+}
+
+func TestAdapt_WithRenderer_ReplacesDefault(t *testing.T) {
+	h := negotiatingHandler(t, handler.WithRenderer("application/json", handler.RendererFunc(func(w io.Writer, v any) error {
+		_, err := io.WriteString(w, `{"custom":true}`)
+		return err
+	})))
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := w.Body.String(); got != `{"custom":true}` {
+		t.Fatalf("body = %q, want custom JSON", got)
+	}
+	if got := w.Header().Get("Vary"); got != "" {
+		t.Fatalf("Vary = %q, want none with a single renderer", got)
+	}
+}
+
+func TestAdapt_WithRenderer_InvalidMediaType(t *testing.T) {
+	if _, err := handler.Adapt(func(req struct{}) {}, handler.WithRenderer("text/*", textRenderer)); err == nil {
+		t.Fatal("expected error for wildcard media type, got nil")
+	}
+}
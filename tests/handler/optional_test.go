@@ -0,0 +1,153 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+type optionalInput struct {
+	Query  *string                     `json:"query:q"`
+	Page   *int                        `json:"query:page"`
+	Filter handler.Optional[string]    `json:"query:filter"`
+	Limit  handler.Optional[int]       `json:"header:X-Limit"`
+	Since  handler.Optional[time.Time] `json:"query:since,layout=DateOnly"`
+	IDs    handler.Optional[[]int]     `json:"query:ids,csv"`
+	Theme  handler.Optional[string]    `json:"cookie:theme"`
+	Region *string                     `json:"cookie:region"`
+	Note   handler.Optional[string]    `json:"form:note"`
+}
+
+func bindOptional(t *testing.T, req *http.Request) optionalInput {
+	t.Helper()
+	var got optionalInput
+	h, err := handler.Adapt(func(in optionalInput) error {
+		got = in
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	return got
+}
+
+func TestAdapt_AbsentValuesStayUnset(t *testing.T) {
+	got := bindOptional(t, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got.Query != nil || got.Page != nil || got.Region != nil {
+		t.Fatalf("pointers = %v, %v, %v; want nil", got.Query, got.Page, got.Region)
+	}
+	if got.Filter.Present || got.Limit.Present || got.Since.Present || got.IDs.Present || got.Theme.Present || got.Note.Present {
+		t.Fatalf("optionals should be absent: %+v", got)
+	}
+	if got.Limit.OrElse(25) != 25 {
+		t.Fatalf("OrElse() = %d, want 25", got.Limit.OrElse(25))
+	}
+}
+
+func TestAdapt_EmptyValuesArePresent(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?q=&page=&filter=&ids=", nil)
+	req.Header.Set("X-Limit", "")
+	req.AddCookie(&http.Cookie{Name: "theme", Value: ""})
+	got := bindOptional(t, req)
+
+	if got.Query == nil || *got.Query != "" {
+		t.Fatalf("Query = %v, want pointer to empty string", got.Query)
+	}
+	if got.Page == nil || *got.Page != 0 {
+		t.Fatalf("Page = %v, want pointer to 0", got.Page)
+	}
+	if v, ok := got.Filter.Get(); !ok || v != "" {
+		t.Fatalf("Filter = %+v, want present and empty", got.Filter)
+	}
+	if !got.IDs.Present || got.IDs.Value != nil {
+		t.Fatalf("IDs = %+v, want present with nil slice", got.IDs)
+	}
+	if !got.Theme.Present {
+		t.Fatalf("Theme = %+v, want present", got.Theme)
+	}
+}
+
+func TestAdapt_OptionalValuesConvert(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?filter=active&since=2024-05-01&ids=1,2", nil)
+	req.Header.Set("X-Limit", "10")
+	got := bindOptional(t, req)
+
+	if got.Filter.Value != "active" || got.Limit.Value != 10 || !got.Limit.Present {
+		t.Fatalf("unexpected mapping: %+v", got)
+	}
+	if !got.Since.Value.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) || len(got.IDs.Value) != 2 {
+		t.Fatalf("unexpected mapping: %+v", got)
+	}
+}
+
+func TestAdapt_OptionalPathParam(t *testing.T) {
+	type input struct {
+		Version handler.Optional[int] `json:"path:version"`
+	}
+
+	var got input
+	h, err := handler.Adapt(func(in input) error {
+		got = in
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if w.Code != http.StatusNoContent || got.Version.Present {
+		t.Fatalf("status = %d, version = %+v; want 204 and absent", w.Code, got.Version)
+	}
+}
+
+func TestAdapt_OptionalRejectsDefault(t *testing.T) {
+	_, err := handler.Adapt(func(in struct {
+		Page handler.Optional[int] `json:"query:page,default=1"`
+	}) {
+	})
+	if err == nil {
+		t.Fatal("expected error for default on Optional field, got nil")
+	}
+}
+
+func TestAdapt_OptionalValidation(t *testing.T) {
+	h, err := handler.Adapt(func(in struct {
+		Query handler.Optional[string] `json:"query:q" validate:"min=2"`
+		Page  handler.Optional[int]    `json:"query:page" validate:"required,max=10"`
+	}) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	tests := []struct {
+		query  string
+		status int
+	}{
+		{query: "page=3", status: http.StatusNoContent},      // absent q skips min
+		{query: "q=go&page=3", status: http.StatusNoContent}, // present q passes min
+		{query: "q=g&page=3", status: http.StatusUnprocessableEntity},
+		{query: "page=", status: http.StatusNoContent},             // present counts as required
+		{query: "q=go", status: http.StatusUnprocessableEntity},    // absent page fails required
+		{query: "page=11", status: http.StatusUnprocessableEntity}, // max applies to the value
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil))
+		if w.Code != tt.status {
+			t.Errorf("%q: status = %d, want %d: %s", tt.query, w.Code, tt.status, w.Body.String())
+		}
+	}
+}
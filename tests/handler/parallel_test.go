@@ -0,0 +1,155 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+// lookupResolver simulates a cache or auth lookup that blocks for delay.
+type lookupResolver struct {
+	fieldIdx int
+	name     string
+	delay    time.Duration
+	fail     bool
+}
+
+func (r *lookupResolver) FieldIndex() int { return r.fieldIdx }
+func (r *lookupResolver) Blocking() bool  { return true }
+
+func (r *lookupResolver) Resolve(ctx *handler.Context) (reflect.Value, error) {
+	select {
+	case <-time.After(r.delay):
+	case <-ctx.Request.Context().Done():
+		return reflect.Value{}, ctx.Request.Context().Err()
+	}
+	if r.fail {
+		return reflect.Value{}, fmt.Errorf("lookup %q failed", r.name)
+	}
+	return reflect.ValueOf("value-of-" + r.name), nil
+}
+
+// lookupOption registers the "lookup" source; names prefixed with "fail-"
+// fail and names of the form "<name>@<ms>" block for that many milliseconds.
+func lookupOption() handler.Option {
+	return handler.WithResolver("lookup", func(fieldIdx int, name string, fieldType reflect.Type) (handler.FieldResolver, error) {
+		if fieldType.Kind() != reflect.String {
+			return nil, errors.New("lookup fields must be strings")
+		}
+		r := &lookupResolver{fieldIdx: fieldIdx, name: name, delay: 50 * time.Millisecond}
+		if base, ms, ok := strings.Cut(name, "@"); ok {
+			var d int
+			_, _ = fmt.Sscanf(ms, "%d", &d)
+			r.name, r.delay = base, time.Duration(d)*time.Millisecond
+		}
+		r.fail = strings.HasPrefix(r.name, "fail-")
+		return r, nil
+	})
+}
+
+func TestAdapt_BlockingResolversRunConcurrently(t *testing.T) {
+	type input struct {
+		User    string `json:"lookup:user"`
+		Tenant  string `json:"lookup:tenant"`
+		Profile string `json:"lookup:profile"`
+		Page    int    `json:"query:page"`
+	}
+
+	h, err := handler.Adapt(func(req input) (map[string]any, error) {
+		return map[string]any{"user": req.User, "tenant": req.Tenant, "profile": req.Profile, "page": req.Page}, nil
+	}, lookupOption())
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/me?page=2", nil))
+	elapsed := time.Since(start)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if elapsed >= 140*time.Millisecond {
+		t.Fatalf("elapsed = %v, want concurrent resolution (< 140ms)", elapsed)
+	}
+
+	var got map[string]any
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+	if got["user"] != "value-of-user" || got["tenant"] != "value-of-tenant" || got["page"] != float64(2) {
+		t.Fatalf("unexpected mapping: %+v", got)
+	}
+}
+
+func TestAdapt_BlockingResolverErrorsFollowFieldOrder(t *testing.T) {
+	type input struct {
+		Slow string `json:"lookup:fail-slow@40"`
+		Fast string `json:"lookup:fail-fast@1"`
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil }, lookupOption())
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(w.Body.String(), "fail-slow") {
+		t.Fatalf("body = %s, want error from first field", w.Body.String())
+	}
+}
+
+func TestAdapt_BlockingResolversObserveRequestCancellation(t *testing.T) {
+	type input struct {
+		User string `json:"lookup:user@5000"`
+	}
+
+	called := false
+	h, err := handler.Adapt(func(req input) error {
+		called = true
+		return nil
+	}, lookupOption())
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+
+	if time.Since(start) > time.Second {
+		t.Fatal("resolver did not observe request cancellation")
+	}
+	if called {
+		t.Fatal("handler should not run after resolution was cancelled")
+	}
+}
+
+func TestAdapt_WithResolver_RejectsBuiltinSource(t *testing.T) {
+	type input struct {
+		Name string `json:"query:name"`
+	}
+
+	factory := func(int, string, reflect.Type) (handler.FieldResolver, error) { return nil, nil }
+	if _, err := handler.Adapt(func(req input) error { return nil }, handler.WithResolver("query", factory)); err == nil {
+		t.Fatal("expected error when overriding a built-in source, got nil")
+	}
+}
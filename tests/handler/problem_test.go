@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("Content-Type = %q, want application/problem+json", ct)
+	}
+	var got map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return got
+}
+
+func TestProblemRenderer_HandlerError(t *testing.T) {
+	h, err := handler.Adapt(func(req struct{}) error {
+		return handler.NotFound("user 42 does not exist").WithDetails(map[string]any{"user_id": 42, "status": "ignored"})
+	}, handler.WithErrorRenderer(handler.ProblemRenderer{
+		TypeURI: func(status int) string { return "https://errors.example.com/not-found" },
+	}))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/users/42?expand=1", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+
+	got := decodeProblem(t, w)
+	want := map[string]any{
+		"type":     "https://errors.example.com/not-found",
+		"title":    "Not Found",
+		"status":   float64(404),
+		"detail":   "user 42 does not exist",
+		"instance": "/users/42?expand=1",
+		"user_id":  float64(42),
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+func TestProblemRenderer_ValidationAndBindingFailures(t *testing.T) {
+	type input struct {
+		Body struct {
+			Email string `json:"email" validate:"email"`
+		} `json:"body"`
+		Page int `json:"query:page"`
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil }, handler.WithErrorRenderer(handler.ProblemRenderer{}))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/signup", bytes.NewBufferString(`{"email":"nope"}`)))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	got := decodeProblem(t, w)
+	if got["type"] != "about:blank" || got["title"] != "Unprocessable Entity" {
+		t.Fatalf("unexpected problem: %+v", got)
+	}
+	if errs, ok := got["errors"].([]any); !ok || len(errs) != 1 {
+		t.Fatalf("errors = %v, want one field error", got["errors"])
+	}
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/signup?page=x", bytes.NewBufferString(`{"email":"a@b.co"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if got := decodeProblem(t, w); got["status"] != float64(400) || got["detail"] == nil {
+		t.Fatalf("unexpected problem: %+v", got)
+	}
+}
+
+func TestAdapt_CustomErrorRenderer(t *testing.T) {
+	renderer := handler.ErrorRendererFunc(func(w http.ResponseWriter, r *http.Request, resp *handler.ErrorResponse) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(resp.Status)
+		_, _ = w.Write([]byte(resp.Message))
+	})
+
+	h, err := handler.Adapt(func(req struct{}) error { return handler.Conflict("taken") }, handler.WithErrorRenderer(renderer))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusConflict || w.Body.String() != "taken" {
+		t.Fatalf("got %d %q, want 409 %q", w.Code, w.Body.String(), "taken")
+	}
+}
@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"net/http"
@@ -74,6 +75,42 @@ func TestBodyResolver_Resolve_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestBodyResolver_DecodesByContentType(t *testing.T) {
+	decoders := map[string]handler.Decoder{
+		"application/json": handler.JSONDecoder{},
+		"application/xml":  handler.XMLDecoder{},
+	}
+	resolver := handler.NewBodyResolverWithDecoders(0, reflect.TypeOf(testBody{}), decoders)
+
+	tests := map[string]string{
+		"application/xml; charset=utf-8": `<testBody><Name>ann</Name><Age>40</Age></testBody>`,
+		"application/vnd.api+json":       `{"name":"ann","age":40}`,
+	}
+	for contentType, payload := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(payload))
+		req.Header.Set("Content-Type", contentType)
+
+		value, err := resolver.Resolve(&handler.Context{Request: req})
+		if err != nil {
+			t.Fatalf("%s: Resolve() error = %v", contentType, err)
+		}
+		if got := value.Interface().(testBody); got.Name != "ann" || got.Age != 40 {
+			t.Fatalf("%s: resolved value = %+v, want {Name:ann Age:40}", contentType, got)
+		}
+	}
+}
+
+func TestBodyResolver_UnsupportedMediaType(t *testing.T) {
+	resolver := handler.NewBodyResolver(0, reflect.TypeOf(testBody{}))
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`name: ann`))
+	req.Header.Set("Content-Type", "application/yaml")
+
+	_, err := resolver.Resolve(&handler.Context{Request: req})
+	if !errors.Is(err, handler.ErrUnsupportedMediaType) {
+		t.Fatalf("Resolve() error = %v, want ErrUnsupportedMediaType", err)
+	}
+}
+
 func TestHeaderResolver_Resolve(t *testing.T) {
 	resolver := handler.NewHeaderResolver(1, "X-Retry", reflect.TypeOf(0))
 	req := httptest.NewRequest(http.MethodGet, "/items", nil)
@@ -0,0 +1,111 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+type createdUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestAdapt_ResponseSetsStatusHeadersAndCookies(t *testing.T) {
+	type input struct {
+		Name string `json:"query:name"`
+	}
+
+	h, err := handler.Adapt(func(req input) (handler.Response[*createdUser], error) {
+		return handler.Response[*createdUser]{
+			Status:  http.StatusCreated,
+			Header:  http.Header{"Location": {"/users/42"}},
+			Cookies: []*http.Cookie{{Name: "last", Value: "42"}},
+			Body:    &createdUser{ID: "42", Name: req.Name},
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/users?name=ada", nil))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+	}
+	if got := w.Header().Get("Location"); got != "/users/42" {
+		t.Fatalf("Location = %q, want %q", got, "/users/42")
+	}
+	if got := w.Header().Get("Set-Cookie"); got != "last=42" {
+		t.Fatalf("Set-Cookie = %q, want %q", got, "last=42")
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("Content-Type = %q, want application/json", got)
+	}
+
+	var got createdUser
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+	if got.ID != "42" || got.Name != "ada" {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestAdapt_ResponseDefaultsAndNoContent(t *testing.T) {
+	status := 0
+	h, err := handler.Adapt(func(req struct{}) handler.Response[map[string]int] {
+		return handler.Response[map[string]int]{Status: status, Body: map[string]int{"n": 1}}
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || w.Body.Len() == 0 {
+		t.Fatalf("status = %d, body = %q; want 200 with body", w.Code, w.Body.String())
+	}
+
+	status = http.StatusNoContent
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+		t.Fatalf("status = %d, body = %q; want 204 without body", w.Code, w.Body.String())
+	}
+}
+
+func TestAdapt_ResponseInvalidStatus_Returns500(t *testing.T) {
+	h, err := handler.Adapt(func(req struct{}) handler.Response[string] {
+		return handler.Response[string]{Status: 42}
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestAdapt_ResponseSignatureValidation(t *testing.T) {
+	tests := map[string]any{
+		"pointer":      func(req struct{}) (*handler.Response[string], error) { return nil, nil },
+		"extra output": func(req struct{}) (handler.Response[string], int, error) { return handler.Response[string]{}, 0, nil },
+		"not first":    func(req struct{}) (int, handler.Response[string]) { return 0, handler.Response[string]{} },
+	}
+
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := handler.Adapt(fn); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+func TestQueryResolver_SliceCollectsRepeatedValues(t *testing.T) {
+	resolver := handler.NewQueryResolver(0, "tag", reflect.TypeOf([]string{}))
+	req := httptest.NewRequest(http.MethodGet, "/?tag=a&tag=b&tag=", nil)
+
+	value, err := resolver.Resolve(&handler.Context{Request: req})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got := value.Interface().([]string); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("resolved value = %v, want [a b]", got)
+	}
+}
+
+func TestQueryResolver_SliceCSV(t *testing.T) {
+	resolver := handler.NewQueryResolverWithOptions(0, "ids", reflect.TypeOf([]int{}), handler.ValueOptions{CSV: true})
+	req := httptest.NewRequest(http.MethodGet, "/?ids=1,2&ids=3", nil)
+
+	value, err := resolver.Resolve(&handler.Context{Request: req})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got := value.Interface().([]int); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("resolved value = %v, want [1 2 3]", got)
+	}
+}
+
+func TestQueryResolver_SliceConversionErrorReportsItem(t *testing.T) {
+	resolver := handler.NewQueryResolverWithOptions(0, "ids", reflect.TypeOf([]int{}), handler.ValueOptions{CSV: true})
+	req := httptest.NewRequest(http.MethodGet, "/?ids=1,x", nil)
+
+	_, err := resolver.Resolve(&handler.Context{Request: req})
+	var re *handler.ResolveError
+	if !errors.As(err, &re) || re.Raw != "x" {
+		t.Fatalf("Resolve() error = %v, want ResolveError with Raw x", err)
+	}
+}
+
+func TestQueryResolver_MissingSliceIsNil(t *testing.T) {
+	resolver := handler.NewQueryResolver(0, "tag", reflect.TypeOf([]string{}))
+	value, err := resolver.Resolve(&handler.Context{Request: httptest.NewRequest(http.MethodGet, "/", nil)})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if !value.IsNil() {
+		t.Fatalf("resolved value = %v, want nil slice", value.Interface())
+	}
+}
+
+func TestHeaderResolver_SliceCollectsLines(t *testing.T) {
+	resolver := handler.NewHeaderResolverWithOptions(0, "X-Scope", reflect.TypeOf([]string{}), handler.ValueOptions{CSV: true})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("X-Scope", "read, write")
+	req.Header.Add("X-Scope", "admin")
+
+	value, err := resolver.Resolve(&handler.Context{Request: req})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got := value.Interface().([]string); !reflect.DeepEqual(got, []string{"read", "write", "admin"}) {
+		t.Fatalf("resolved value = %v, want [read write admin]", got)
+	}
+}
+
+func TestFormResolver_SliceCollectsValues(t *testing.T) {
+	resolver := handler.NewFormResolver(0, "score", reflect.TypeOf([]float64{}))
+	form := url.Values{"score": {"1.5", "2"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	value, err := resolver.Resolve(&handler.Context{Request: req})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got := value.Interface().([]float64); !reflect.DeepEqual(got, []float64{1.5, 2}) {
+		t.Fatalf("resolved value = %v, want [1.5 2]", got)
+	}
+}
+
+func TestAdapt_SliceTags(t *testing.T) {
+	type input struct {
+		Tags []string `json:"query:tag"`
+		IDs  []int64  `json:"query:ids,csv"`
+	}
+
+	h, err := handler.Adapt(func(req input) (map[string]any, error) {
+		return map[string]any{"tags": req.Tags, "ids": req.IDs}, nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?tag=go&tag=web&ids=7,8", nil))
+
+	var got struct {
+		Tags []string `json:"tags"`
+		IDs  []int64  `json:"ids"`
+	}
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+	if !reflect.DeepEqual(got.Tags, []string{"go", "web"}) || !reflect.DeepEqual(got.IDs, []int64{7, 8}) {
+		t.Fatalf("unexpected mapping: %+v", got)
+	}
+}
+
+func TestAdapt_CSVOptionValidation(t *testing.T) {
+	tests := map[string]any{
+		"non-slice": func(req struct {
+			ID int `json:"query:id,csv"`
+		}) {
+		},
+		"cookie": func(req struct {
+			IDs []int `json:"cookie:ids,csv"`
+		}) {
+		},
+	}
+
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := handler.Adapt(fn); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+func strictJSONHandler(t *testing.T, decoder handler.JSONDecoder, opts ...handler.Option) http.HandlerFunc {
+	t.Helper()
+	type input struct {
+		Body map[string]any `json:"body"`
+	}
+
+	opts = append(opts, handler.WithDecoder("application/json", decoder))
+	h, err := handler.Adapt(func(req input) (map[string]any, error) {
+		return req.Body, nil
+	}, opts...)
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+	return h
+}
+
+func TestJSONDecoder_DisallowUnknownFields(t *testing.T) {
+	type input struct {
+		Body createUserBody `json:"body"`
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil },
+		handler.WithDecoder("application/json", handler.JSONDecoder{DisallowUnknownFields: true}))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"ann","admin":true}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestJSONDecoder_UseNumber(t *testing.T) {
+	var got any
+	type input struct {
+		Body map[string]any `json:"body"`
+	}
+
+	h, err := handler.Adapt(func(req input) error {
+		got = req.Body["id"]
+		return nil
+	}, handler.WithDecoder("application/json", handler.JSONDecoder{UseNumber: true}))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":9007199254740993}`)))
+	if n, ok := got.(json.Number); !ok || n.String() != "9007199254740993" {
+		t.Fatalf("id = %#v, want json.Number 9007199254740993", got)
+	}
+}
+
+func TestJSONDecoder_DisallowTrailingData(t *testing.T) {
+	h := strictJSONHandler(t, handler.JSONDecoder{DisallowTrailingData: true})
+
+	tests := map[string]int{
+		`{"a":1}`:         http.StatusOK,
+		"{\"a\":1}\n  \n": http.StatusOK,
+		`{"a":1} garbage`: http.StatusBadRequest,
+		`{"a":1}{"b":2}`:  http.StatusBadRequest,
+	}
+	for payload, want := range tests {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+		if w.Code != want {
+			t.Fatalf("%q: status = %d, want %d", payload, w.Code, want)
+		}
+	}
+
+	lenient := strictJSONHandler(t, handler.JSONDecoder{})
+	w := httptest.NewRecorder()
+	lenient(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"a":1} garbage`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want trailing data accepted by default", w.Code)
+	}
+}
+
+func TestAdapt_MaxBodySize_Returns413(t *testing.T) {
+	h := strictJSONHandler(t, handler.JSONDecoder{}, handler.WithMaxBodySize(16))
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"a":1}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"`+strings.Repeat("x", 64)+`"}`)))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+	if !strings.Contains(w.Body.String(), "request body too large") {
+		t.Fatalf("body = %s, want size message", w.Body.String())
+	}
+}
+
+func TestAdapt_MaxBodySize_Multipart(t *testing.T) {
+	type input struct {
+		Name string `json:"form:name"`
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil }, handler.WithMaxBodySize(64))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("name", strings.Repeat("x", 256))
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestWithMaxBodySize_RejectsNonPositive(t *testing.T) {
+	if _, err := handler.Adapt(func(req struct{}) {}, handler.WithMaxBodySize(0)); err == nil {
+		t.Fatal("expected error for zero limit, got nil")
+	}
+}
@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+func TestAdapt_DefaultValues(t *testing.T) {
+	type input struct {
+		Page   int      `json:"query:page,default=1"`
+		Sort   string   `json:"query:sort,default=name"`
+		Tags   []string `json:"query:tag,default=all"`
+		Lang   string   `json:"header:Accept-Language,default=en"`
+		Theme  string   `json:"cookie:theme,default=light"`
+		Strict bool     `json:"query:strict,default=true"`
+	}
+
+	var got input
+	h, err := handler.Adapt(func(req input) error {
+		got = req
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?page=&strict=false", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	want := input{Page: 1, Sort: "name", Tags: []string{"all"}, Lang: "en", Theme: "light", Strict: false}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?page=3&sort=age&tag=go", nil)
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	h(httptest.NewRecorder(), req)
+	if got.Page != 3 || got.Sort != "age" || !reflect.DeepEqual(got.Tags, []string{"go"}) || got.Theme != "dark" {
+		t.Fatalf("request values should override defaults: %+v", got)
+	}
+}
+
+func TestAdapt_RequiredValues(t *testing.T) {
+	type input struct {
+		Tenant string `json:"header:X-Tenant,required"`
+		IDs    []int  `json:"query:ids,csv,required"`
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil }, handler.WithAllBindingErrors())
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?ids=,", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	body := w.Body.String()
+	for _, want := range []string{`missing header \"X-Tenant\"`, `missing query parameter \"ids\"`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("body = %s, want %q", body, want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?ids=1", nil)
+	req.Header.Set("X-Tenant", "acme")
+	w = httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+}
+
+func TestAdapt_DefaultOptionValidation(t *testing.T) {
+	tests := map[string]any{
+		"unconvertible": func(req struct {
+			Page int `json:"query:page,default=first"`
+		}) {
+		},
+		"empty": func(req struct {
+			Page int `json:"query:page,default="`
+		}) {
+		},
+		"path": func(req struct {
+			ID int `json:"path:id,default=1"`
+		}) {
+		},
+		"default and required": func(req struct {
+			Page int `json:"query:page,default=1,required"`
+		}) {
+		},
+	}
+
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := handler.Adapt(fn); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+func TestAdapt_TimeLayouts(t *testing.T) {
+	type input struct {
+		Default  time.Time     `json:"query:default"`
+		Date     time.Time     `json:"query:date,layout=2006-01-02"`
+		Named    *time.Time    `json:"header:If-Modified-Since,layout=RFC1123"`
+		Unix     time.Time     `json:"query:unix,layout=unix"`
+		Millis   []time.Time   `json:"query:ms,csv,layout=unixmilli"`
+		Day      time.Time     `json:"path:day,layout=DateOnly"`
+		Timeout  time.Duration `json:"query:timeout"`
+		Interval time.Duration `json:"cookie:interval"`
+	}
+
+	var got input
+	h, err := handler.Adapt(func(req input) error {
+		got = req
+		return nil
+	}, handler.WithPattern("/reports/:day"))
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?default=2024-05-01T10:00:00Z&date=2024-05-02&unix=1700000000&ms=1700000000000,1700000000500&timeout=1m30s", nil)
+	req.Header.Set("If-Modified-Since", "Wed, 01 May 2024 10:00:00 GMT")
+	req.AddCookie(&http.Cookie{Name: "interval", Value: "250ms"})
+	req = handler.WithParams(req, map[string]string{"day": "2024-05-03"})
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+	checks := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"default", got.Default, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{"date", got.Date, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)},
+		{"unix", got.Unix, time.Unix(1700000000, 0)},
+		{"day", got.Day, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range checks {
+		if !c.got.Equal(c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if got.Named == nil || !got.Named.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("named = %v, want 2024-05-01 10:00 UTC", got.Named)
+	}
+	if len(got.Millis) != 2 || got.Millis[1].Sub(got.Millis[0]) != 500*time.Millisecond {
+		t.Errorf("millis = %v, want two times 500ms apart", got.Millis)
+	}
+	if got.Timeout != 90*time.Second || got.Interval != 250*time.Millisecond {
+		t.Errorf("durations = %v, %v; want 1m30s, 250ms", got.Timeout, got.Interval)
+	}
+}
+
+func TestAdapt_TimeParseFailureMessage(t *testing.T) {
+	type input struct {
+		Since   time.Time     `json:"query:since,layout=2006-01-02"`
+		Timeout time.Duration `json:"query:timeout"`
+	}
+
+	h, err := handler.Adapt(func(req input) error { return nil }, handler.WithAllBindingErrors())
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?since=yesterday&timeout=soon", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`invalid query parameter \"since\": expected a time in the layout 2006-01-02`,
+		`invalid query parameter \"timeout\": expected a duration`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("body = %s, want %q", body, want)
+		}
+	}
+}
+
+func TestAdapt_LayoutOptionValidation(t *testing.T) {
+	tests := map[string]any{
+		"non-time": func(req struct {
+			Page int `json:"query:page,layout=2006"`
+		}) {
+		},
+		"empty": func(req struct {
+			Since time.Time `json:"query:since,layout="`
+		}) {
+		},
+	}
+
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := handler.Adapt(fn); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestQueryResolver_TimeDefaultsToRFC3339(t *testing.T) {
+	resolver := handler.NewQueryResolver(0, "at", reflect.TypeOf(time.Time{}))
+	req := httptest.NewRequest(http.MethodGet, "/?at=2024-05-01T10:00:00.5%2B02:00", nil)
+
+	value, err := resolver.Resolve(&handler.Context{Request: req})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	want := time.Date(2024, 5, 1, 8, 0, 0, 500_000_000, time.UTC)
+	if got := value.Interface().(time.Time); !got.Equal(want) {
+		t.Fatalf("resolved value = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package handler_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+func TestAdapt_UploadConstraints(t *testing.T) {
+	var got []*multipart.FileHeader
+	h, err := handler.Adapt(func(req struct {
+		Photos []*multipart.FileHeader `json:"file:photos,maxsize=1KB,types=image/png|image/gif,maxcount=2"`
+	}) error {
+		got = req.Photos
+		return nil
+	}, handler.WithAllBindingErrors())
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		files  [][2]string
+		status int
+		msg    string
+	}{
+		{name: "accepted", files: [][2]string{{"photos", pngHeader + "a"}, {"photos", "GIF89a..."}}, status: http.StatusNoContent},
+		{name: "too large", files: [][2]string{{"photos", pngHeader + strings.Repeat("x", 1024)}}, status: http.StatusRequestEntityTooLarge, msg: `invalid file \"photos\": expected at most 1KB`},
+		{name: "too many", files: [][2]string{{"photos", pngHeader}, {"photos", pngHeader}, {"photos", pngHeader}}, status: http.StatusRequestEntityTooLarge, msg: "expected at most 2 files"},
+		{name: "wrong type", files: [][2]string{{"photos", "plain text"}}, status: http.StatusUnsupportedMediaType, msg: "expected image/png or image/gif"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h(w, newMultipartRequest(t, nil, tt.files...))
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.msg) {
+				t.Fatalf("body = %s, want %q", w.Body.String(), tt.msg)
+			}
+		})
+	}
+	if len(got) != 2 {
+		t.Fatalf("accepted request bound %d files, want 2", len(got))
+	}
+}
+
+func TestAdapt_UploadTypeIsSniffed(t *testing.T) {
+	h, err := handler.Adapt(func(req struct {
+		Avatar *multipart.FileHeader `json:"file:avatar,types=image/*"`
+	}) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	send := func(contentType, content string) int {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		hdr := textproto.MIMEHeader{}
+		hdr.Set("Content-Disposition", `form-data; name="avatar"; filename="avatar.png"`)
+		hdr.Set("Content-Type", contentType)
+		part, _ := mw.CreatePart(hdr)
+		_, _ = part.Write([]byte(content))
+		_ = mw.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		h(w, req)
+		return w.Code
+	}
+
+	if code := send("image/png", "<html>not an image</html>"); code != http.StatusUnsupportedMediaType {
+		t.Fatalf("spoofed image: status = %d, want %d", code, http.StatusUnsupportedMediaType)
+	}
+	if code := send("application/octet-stream", pngHeader+"data"); code != http.StatusNoContent {
+		t.Fatalf("real image: status = %d, want %d", code, http.StatusNoContent)
+	}
+}
+
+func TestAdapt_InvalidUploadOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   any
+		want string
+	}{
+		{
+			name: "bad size",
+			fn: func(req struct {
+				F *multipart.FileHeader `json:"file:f,maxsize=lots"`
+			}) error {
+				return nil
+			},
+			want: `invalid size "lots"`,
+		},
+		{
+			name: "bad type",
+			fn: func(req struct {
+				F *multipart.FileHeader `json:"file:f,types=png"`
+			}) error {
+				return nil
+			},
+			want: `invalid media type "png"`,
+		},
+		{
+			name: "bad count",
+			fn: func(req struct {
+				F []*multipart.FileHeader `json:"file:f,maxcount=0"`
+			}) error {
+				return nil
+			},
+			want: "must be a positive integer",
+		},
+		{
+			name: "count on single file",
+			fn: func(req struct {
+				F *multipart.FileHeader `json:"file:f,maxcount=2"`
+			}) error {
+				return nil
+			},
+			want: "requires []*multipart.FileHeader",
+		},
+		{
+			name: "non-file tag",
+			fn: func(req struct {
+				Q string `json:"query:q,maxsize=1KB"`
+			}) error {
+				return nil
+			},
+			want: "only supported for file tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.Adapt(tt.fn)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Adapt() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
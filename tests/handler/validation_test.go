@@ -0,0 +1,204 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handler "github.com/sohamratnaparkhi/go-fast/pkg/handler"
+)
+
+type signupAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"len=5"`
+}
+
+type signupBody struct {
+	Username string          `json:"username" validate:"required,pattern=^[a-z]+$"`
+	Email    string          `json:"email" validate:"email"`
+	Plan     string          `json:"plan" validate:"oneof=free pro"`
+	Address  signupAddress   `json:"address"`
+	Contacts []signupAddress `json:"contacts"`
+}
+
+type signupInput struct {
+	Body   signupBody `json:"body"`
+	Page   int        `json:"query:page" validate:"min=1,max=100"`
+	Tenant string     `json:"header:X-Tenant" validate:"required"`
+	Limit  *int       `json:"query:limit" validate:"max=50"`
+}
+
+type validationResponse struct {
+	Error  string               `json:"error"`
+	Fields []handler.FieldError `json:"fields"`
+}
+
+func TestAdapt_Validation_ListsEveryFailingField(t *testing.T) {
+	called := false
+	h, err := handler.Adapt(func(req signupInput) error {
+		called = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	body := `{"username":"Bad Name","email":"nope","plan":"gold","address":{"zip":"123"},"contacts":[{"city":"x","zip":"12345"},{"zip":"1"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/signup?page=0&limit=99", bytes.NewBufferString(body))
+
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if called {
+		t.Fatal("handler ran despite validation failure")
+	}
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+
+	var got validationResponse
+	if decodeErr := json.NewDecoder(w.Body).Decode(&got); decodeErr != nil {
+		t.Fatalf("decode response: %v", decodeErr)
+	}
+
+	want := map[string]string{
+		"username":         "body:pattern",
+		"email":            "body:email",
+		"plan":             "body:oneof",
+		"address.city":     "body:required",
+		"address.zip":      "body:len",
+		"contacts[1].city": "body:required",
+		"contacts[1].zip":  "body:len",
+		"page":             "query:min",
+		"X-Tenant":         "header:required",
+		"limit":            "query:max",
+	}
+	if len(got.Fields) != len(want) {
+		t.Fatalf("fields = %+v, want %d entries", got.Fields, len(want))
+	}
+	for _, fe := range got.Fields {
+		if want[fe.Field] != fe.Source+":"+fe.Rule {
+			t.Errorf("unexpected field error %+v", fe)
+		}
+	}
+}
+
+func TestAdapt_Validation_PassesValidInput(t *testing.T) {
+	h, err := handler.Adapt(func(req signupInput) error { return nil })
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	body := `{"username":"ada","email":"ada@example.com","plan":"pro","address":{"city":"London","zip":"12345"}}`
+	req := httptest.NewRequest(http.MethodPost, "/signup?page=3", bytes.NewBufferString(body))
+	req.Header.Set("X-Tenant", "acme")
+
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+}
+
+func TestAdapt_Validation_InvalidTagsFailAtStartup(t *testing.T) {
+	cases := []any{
+		func(req struct {
+			Page int `json:"query:page" validate:"pattern=^a$"`
+		}) error {
+			return nil
+		},
+		func(req struct {
+			Name string `json:"query:name" validate:"min=abc"`
+		}) error {
+			return nil
+		},
+		func(req struct {
+			Name string `json:"query:name" validate:"unknown"`
+		}) error {
+			return nil
+		},
+		func(req struct {
+			Name string `json:"query:name" validate:"pattern=["`
+		}) error {
+			return nil
+		},
+	}
+
+	for i, fn := range cases {
+		if _, err := handler.Adapt(fn); err == nil {
+			t.Errorf("case %d: expected startup error, got nil", i)
+		}
+	}
+}
+
+type threadComment struct {
+	Text    string          `json:"text"`
+	Replies []threadComment `json:"replies"`
+	Parent  *threadComment  `json:"parent"`
+}
+
+type ratedComment struct {
+	Text    string         `json:"text" validate:"required"`
+	Replies []ratedComment `json:"replies"`
+}
+
+func TestAdapt_Validation_RecursiveBody(t *testing.T) {
+	if _, err := handler.Adapt(func(req struct {
+		Body   threadComment `json:"body"`
+		Thread threadComment `json:"query:thread,deep"`
+	}) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	h, err := handler.Adapt(func(req struct {
+		Body ratedComment `json:"body"`
+	}) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	payload := `{"text":"root","replies":[{"text":"a"},{"text":"b","replies":[{"text":""}]}]}`
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(payload)))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusUnprocessableEntity, w.Body.String())
+	}
+
+	var resp validationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Fields) != 1 || resp.Fields[0].Field != "replies[1].replies[0].text" {
+		t.Fatalf("fields = %+v, want replies[1].replies[0].text", resp.Fields)
+	}
+}
+
+func TestAdapt_Validation_PatternWithComma(t *testing.T) {
+	h, err := handler.Adapt(func(req struct {
+		Code string `json:"query:code" validate:"required,pattern=^[a-z]{1,3}$"`
+	}) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Adapt() error = %v", err)
+	}
+
+	for query, want := range map[string]int{
+		"code=ab":   http.StatusNoContent,
+		"code=abcd": http.StatusUnprocessableEntity,
+		"":          http.StatusUnprocessableEntity,
+	} {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, "/?"+query, nil))
+		if w.Code != want {
+			t.Errorf("%q: status = %d, want %d: %s", query, w.Code, want, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package router_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sohamratnaparkhi/go-fast/pkg/handler"
+	"github.com/sohamratnaparkhi/go-fast/pkg/router"
+)
+
+type userOutput struct {
+	ID   int    `json:"id"`
+	Path string `json:"path,omitempty"`
+}
+
+func TestRouter_PathParamResolvesField(t *testing.T) {
+	r := router.New()
+	r.GET("/users/:id", func(req struct {
+		ID int `json:"path:id"`
+	}) (*userOutput, error) {
+		return &userOutput{ID: req.ID}, nil
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var got userOutput
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.ID != 42 {
+		t.Fatalf("id = %d, want 42", got.ID)
+	}
+}
+
+func TestRouter_CatchAll(t *testing.T) {
+	r := router.New()
+	r.GET("/files/*path", func(req struct {
+		Path string `json:"path:path"`
+	}) (*userOutput, error) {
+		return &userOutput{Path: req.Path}, nil
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/a/b/c.txt", nil))
+
+	var got userOutput
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Path != "a/b/c.txt" {
+		t.Fatalf("path = %q, want %q", got.Path, "a/b/c.txt")
+	}
+}
+
+func TestRouter_StaticBeatsParam(t *testing.T) {
+	r := router.New()
+	if err := r.HandleHTTP(http.MethodGet, "/users/:id", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("param:" + handler.ParamsFromRequest(req)["id"]))
+	})); err != nil {
+		t.Fatalf("HandleHTTP() error = %v", err)
+	}
+	if err := r.HandleHTTP(http.MethodGet, "/users/me", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("static"))
+	})); err != nil {
+		t.Fatalf("HandleHTTP() error = %v", err)
+	}
+	if err := r.HandleHTTP(http.MethodGet, "/users/:id/posts", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("posts:" + handler.ParamsFromRequest(req)["id"]))
+	})); err != nil {
+		t.Fatalf("HandleHTTP() error = %v", err)
+	}
+
+	cases := map[string]string{
+		"/users/me":       "static",
+		"/users/7":        "param:7",
+		"/users/me/posts": "posts:me",
+		"/users/mei":      "param:mei",
+	}
+	for path, want := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if got := w.Body.String(); got != want {
+			t.Errorf("GET %s = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRouter_NotFoundAndMethodNotAllowed(t *testing.T) {
+	r := router.New()
+	r.GET("/users/:id", func(req struct {
+		ID int `json:"path:id"`
+	}) error {
+		return nil
+	})
+	r.DELETE("/users/:id", func(req struct {
+		ID int `json:"path:id"`
+	}) error {
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/accounts/1", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users/1", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+	if allow := w.Header().Get("Allow"); allow != "DELETE, GET" {
+		t.Fatalf("Allow = %q, want %q", allow, "DELETE, GET")
+	}
+}
+
+func TestRouter_RegistrationErrors(t *testing.T) {
+	noop := func(req struct{}) error { return nil }
+
+	r := router.New()
+	if err := r.Handle(http.MethodGet, "/users/:id", noop); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	bad := []string{
+		"users",          // not absolute
+		"/users/:name",   // conflicting wildcard name
+		"/files/*p/more", // catch-all not last
+		"/a/:",           // empty wildcard name
+		"/a/x:id",        // wildcard mid-segment
+		"/users/:id",     // duplicate route
+		"/b/:id/c/:id",   // duplicate wildcard
+	}
+	for _, pattern := range bad {
+		if err := r.Handle(http.MethodGet, pattern, noop); err == nil {
+			t.Errorf("Handle(%q) expected error, got nil", pattern)
+		}
+	}
+
+	typo := func(req struct {
+		ID int `json:"path:usr_id"`
+	}) error {
+		return nil
+	}
+	if err := r.Handle(http.MethodGet, "/accounts/:id", typo); err == nil {
+		t.Error("expected error for path tag missing from pattern, got nil")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("GET with invalid handler should panic")
+		}
+	}()
+	r.GET("/bad", func(a, b string) {})
+}
+
+func TestRouter_MiddlewareOrdering(t *testing.T) {
+	var trace []string
+	record := func(name string) handler.Middleware {
+		return func(call *handler.Call, next func() error) error {
+			trace = append(trace, name)
+			return next()
+		}
+	}
+
+	r := router.New(handler.WithMiddleware(record("new")))
+	r.Use(record("global"))
+
+	api := r.Group("/api")
+	api.Use(record("api"))
+	v1 := api.Group("/v1", handler.WithMiddleware(record("v1")))
+
+	v1.GET("/users/:id", func(req struct {
+		ID int `json:"path:id"`
+	}) (*userOutput, error) {
+		trace = append(trace, "handler")
+		return &userOutput{ID: req.ID}, nil
+	}, handler.WithMiddleware(record("route")))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/users/9", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	want := []string{"new", "global", "api", "v1", "route", "handler"}
+	if len(trace) != len(want) {
+		t.Fatalf("trace = %v, want %v", trace, want)
+	}
+	for i := range want {
+		if trace[i] != want[i] {
+			t.Fatalf("trace = %v, want %v", trace, want)
+		}
+	}
+}
+
+func TestRouter_RenderersPerServerAndRoute(t *testing.T) {
+	r := router.New(handler.WithRenderer("application/xml", handler.XMLRenderer{}))
+	r.GET("/users/:id", func(req struct {
+		ID int `json:"path:id"`
+	}) (*userOutput, error) {
+		return &userOutput{ID: req.ID}, nil
+	})
+	r.GET("/json-only/:id", func(req struct {
+		ID int `json:"path:id"`
+	}) (*userOutput, error) {
+		return &userOutput{ID: req.ID}, nil
+	}, handler.WithRenderer("application/xml", handler.RendererFunc(func(w io.Writer, v any) error {
+		return errors.New("xml disabled")
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+	req.Header.Set("Accept", "application/xml")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if got := w.Header().Get("Content-Type"); got != "application/xml" {
+		t.Fatalf("Content-Type = %q, want application/xml", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/json-only/7", nil)
+	req.Header.Set("Accept", "application/xml")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want route renderer to override server renderer", w.Code)
+	}
+}